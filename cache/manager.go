@@ -193,6 +193,7 @@ func (m *Manager) Delete(ctx context.Context, key string) error {
 }
 
 // DeleteByPrefix 删除指定前缀的所有缓存
+// Redis 中的 key 按 ScanCount 分批删除，批次之间间隔 DeleteInterval
 func (m *Manager) DeleteByPrefix(ctx context.Context, prefix string) error {
 	if err := m.checkClosed(); err != nil {
 		return err
@@ -203,17 +204,40 @@ func (m *Manager) DeleteByPrefix(ctx context.Context, prefix string) error {
 		m.local.deleteByPrefix(prefix)
 	}
 
-	// 扫描并删除 Redis 中的 key
-	keys, err := m.redis.ScanKeys(ctx, prefix+"*", m.opts.ScanCount)
-	if err != nil {
+	// 后端支持时流式扫描并分批删除 Redis 中的 key
+	if deleter, ok := m.redis.(PatternDeleter); ok {
+		_, err := deleter.DeleteByPattern(ctx, prefix+"*", m.opts.ScanCount, m.opts.DeleteInterval)
 		return err
 	}
 
-	if len(keys) > 0 {
-		_, err = m.redis.Del(ctx, keys...)
+	keys, err := m.redis.ScanKeys(ctx, prefix+"*", m.opts.ScanCount)
+	if err != nil {
 		return err
 	}
+	return m.deleteBatches(ctx, keys)
+}
 
+// deleteBatches 按 ScanCount 分批删除 key，批次之间间隔 DeleteInterval
+func (m *Manager) deleteBatches(ctx context.Context, keys []string) error {
+	batch := int(m.opts.ScanCount)
+	if batch <= 0 {
+		batch = len(keys)
+	}
+	for start := 0; start < len(keys); start += batch {
+		if start > 0 && m.opts.DeleteInterval > 0 {
+			timer := time.NewTimer(m.opts.DeleteInterval)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+		end := min(start+batch, len(keys))
+		if _, err := m.redis.Del(ctx, keys[start:end]...); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
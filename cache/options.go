@@ -16,8 +16,11 @@ type Options struct {
 	// LocalCacheMaxSize 本地缓存最大条目数（0 表示不限制）
 	LocalCacheMaxSize int
 
-	// ScanCount 扫描 key 时每次迭代的建议数量
+	// ScanCount 扫描 key 时每次迭代的建议数量，同时作为按前缀删除时每批删除的数量
 	ScanCount int64
+
+	// DeleteInterval 按前缀删除时相邻两批之间的等待时间（0 表示不等待）
+	DeleteInterval time.Duration
 }
 
 // Option 是配置 Manager 的函数类型
@@ -69,3 +72,10 @@ func WithScanCount(count int64) Option {
 	}
 }
 
+// WithDeleteInterval 设置按前缀删除时相邻两批之间的等待时间
+// 用于限制大批量删除对 Redis 的冲击
+func WithDeleteInterval(d time.Duration) Option {
+	return func(o *Options) {
+		o.DeleteInterval = d
+	}
+}
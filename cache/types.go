@@ -25,6 +25,14 @@ type RedisBackend interface {
 	Exists(ctx context.Context, key string) (bool, error)
 }
 
+// PatternDeleter 是 RedisBackend 的可选扩展，支持流式扫描并分批删除。
+// redis.Manager 自动满足此接口；后端未实现时按前缀删除退化为 ScanKeys 加分批 Del。
+type PatternDeleter interface {
+	// DeleteByPattern 分批删除匹配 pattern 的 key，返回删除的数量
+	// batchSize 为每批删除的数量，interval 为相邻两批之间的等待时间
+	DeleteByPattern(ctx context.Context, pattern string, batchSize int64, interval time.Duration) (int64, error)
+}
+
 // localCacheEntry 本地缓存条目
 type localCacheEntry struct {
 	value     []byte
@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/3086953492/gokit/jwt"
+)
+
+// 认证信息在 gin.Context 中的存储键
+const (
+	claimsKey = "gokit.auth.claims"
+	tokenKey  = "gokit.auth.token"
+)
+
+// Claims 返回当前请求已验证的 Claims，未认证（含可选模式下的匿名请求）时返回 (nil, false)
+func Claims(c *gin.Context) (*jwt.Claims, bool) {
+	v, ok := c.Get(claimsKey)
+	if !ok {
+		return nil, false
+	}
+	claims, ok := v.(*jwt.Claims)
+	return claims, ok
+}
+
+// Subject 返回当前请求的用户标识，未认证时返回空字符串
+func Subject(c *gin.Context) string {
+	claims, ok := Claims(c)
+	if !ok {
+		return ""
+	}
+	return claims.Subject
+}
+
+// Token 返回当前请求已验证的原始访问令牌，未认证时返回空字符串。
+// 可配合 jwt.ParseAccessTokenAs 获取强类型扩展字段。
+func Token(c *gin.Context) string {
+	return c.GetString(tokenKey)
+}
+
+// IsAuthenticated 返回当前请求是否已通过认证
+func IsAuthenticated(c *gin.Context) bool {
+	_, ok := Claims(c)
+	return ok
+}
+
+// setAuth 写入认证信息
+func setAuth(c *gin.Context, token string, claims *jwt.Claims) {
+	c.Set(claimsKey, claims)
+	c.Set(tokenKey, token)
+}
@@ -0,0 +1,11 @@
+package auth
+
+import "errors"
+
+var (
+	// ErrNilVerifier 表示 Verifier 为 nil
+	ErrNilVerifier = errors.New("auth: verifier is nil")
+
+	// ErrMissingToken 表示请求未携带访问令牌
+	ErrMissingToken = errors.New("auth: missing token")
+)
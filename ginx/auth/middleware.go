@@ -0,0 +1,188 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/3086953492/gokit/ginx/problem"
+	"github.com/3086953492/gokit/jwt"
+)
+
+// Middleware 返回认证中间件。
+//
+//	r.Use(auth.Middleware(jwtMgr, auth.WithSkipPaths("/login", "/static/**")))
+//
+// 令牌缺失、无效、过期或已撤销时返回 401；Verifier 内部错误（如撤销存储不可用）返回 500。
+// 启用 WithAutoRefresh 时，过期的访问令牌会先尝试使用刷新令牌 Cookie 续期。
+// 若 verifier 为 nil 会 panic，应在路由注册阶段暴露配置错误。
+func Middleware(verifier Verifier, opts ...Option) gin.HandlerFunc {
+	if verifier == nil {
+		panic(ErrNilVerifier)
+	}
+
+	o := defaultOptions()
+	for _, fn := range opts {
+		fn(o)
+	}
+
+	var refresher *autoRefresher
+	if o.Refresher != nil {
+		refresher = newAutoRefresher(o.Refresher, o.Cookies, o.RefreshGrace)
+	}
+
+	return func(c *gin.Context) {
+		if skipPath(o.SkipPaths, c.Request.URL.Path) {
+			c.Next()
+			return
+		}
+
+		token := readToken(c, o.Sources)
+		if token == "" {
+			if o.Optional {
+				c.Next()
+				return
+			}
+			fail(c, o, ErrMissingToken)
+			return
+		}
+
+		claims, err := verifier.ParseAccessTokenContext(c.Request.Context(), token)
+		if err != nil && refresher != nil && errors.Is(err, jwt.ErrTokenExpired) {
+			var refreshed string
+			refreshed, err = refreshAccess(c, refresher, token, err)
+			if err == nil {
+				token = refreshed
+				claims, err = verifier.ParseAccessTokenContext(c.Request.Context(), token)
+			}
+		}
+		if err != nil {
+			fail(c, o, err)
+			return
+		}
+
+		setAuth(c, token, claims)
+		c.Next()
+	}
+}
+
+// refreshAccess 使用刷新令牌 Cookie 续期访问令牌。
+// 未携带刷新令牌时返回原过期错误；刷新令牌无效时清除令牌 Cookie。
+func refreshAccess(c *gin.Context, refresher *autoRefresher, expiredToken string, expired error) (string, error) {
+	token, err := refresher.refresh(c, expiredToken)
+	if err != nil {
+		refresher.clear(c, err)
+		return "", err
+	}
+	if token == "" {
+		return "", expired
+	}
+	return token, nil
+}
+
+// readToken 按顺序从令牌来源读取第一个非空令牌
+func readToken(c *gin.Context, sources []TokenSource) string {
+	for _, src := range sources {
+		if token := src(c); token != "" {
+			return token
+		}
+	}
+	return ""
+}
+
+// fail 设置 WWW-Authenticate 头并输出错误响应
+func fail(c *gin.Context, o *Options, err error) {
+	status, description := classify(err)
+	if status == http.StatusUnauthorized {
+		c.Header("WWW-Authenticate", challenge(o.Realm, err, description))
+	}
+
+	if o.ErrorHandler != nil {
+		o.ErrorHandler(c, err)
+		c.Abort()
+		return
+	}
+
+	if status == http.StatusUnauthorized {
+		problem.Fail(c, status, "Unauthorized", description, "")
+	} else {
+		problem.Fail(c, status, "Internal Server Error", description, "")
+	}
+	c.Abort()
+}
+
+// classify 将验证错误映射为 HTTP 状态码与描述
+func classify(err error) (int, string) {
+	switch {
+	case errors.Is(err, ErrMissingToken):
+		return http.StatusUnauthorized, "missing access token"
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return http.StatusUnauthorized, "the access token expired"
+	case errors.Is(err, jwt.ErrTokenRevoked):
+		return http.StatusUnauthorized, "the access token has been revoked"
+	case errors.Is(err, jwt.ErrRefreshTokenReused):
+		return http.StatusUnauthorized, "the refresh token has been reused"
+	case errors.Is(err, jwt.ErrInvalidToken), errors.Is(err, jwt.ErrInvalidTokenType):
+		return http.StatusUnauthorized, "the access token is invalid"
+	}
+	return http.StatusInternalServerError, "authentication unavailable"
+}
+
+// challenge 构造 RFC 6750 §3 的 WWW-Authenticate 值。
+// 请求未携带令牌时不包含 error 属性。
+func challenge(realm string, err error, description string) string {
+	var params []string
+	if realm != "" {
+		params = append(params, `realm="`+quote(realm)+`"`)
+	}
+	if !errors.Is(err, ErrMissingToken) {
+		params = append(params, `error="invalid_token"`, `error_description="`+quote(description)+`"`)
+	}
+	if len(params) == 0 {
+		return "Bearer"
+	}
+	return "Bearer " + strings.Join(params, ", ")
+}
+
+// quote 转义 quoted-string 中的反斜杠与双引号
+func quote(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
+
+// skipPath 判断请求路径是否匹配任一跳过规则
+func skipPath(patterns []string, p string) bool {
+	for _, pattern := range patterns {
+		if matchPath(pattern, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPath 使用 path.Match 匹配路径，以 "/**" 结尾的模式匹配前缀本身及其全部子路径
+func matchPath(pattern, p string) bool {
+	prefix, ok := strings.CutSuffix(pattern, "/**")
+	if !ok {
+		matched, _ := path.Match(pattern, p)
+		return matched
+	}
+
+	// 截取与前缀相同段数的路径头部进行匹配，使前缀中同样可以使用通配符
+	segments := strings.Count(prefix, "/")
+	head := p
+	for i := 0; i < len(p); i++ {
+		if p[i] != '/' {
+			continue
+		}
+		if segments == 0 {
+			head = p[:i]
+			break
+		}
+		segments--
+	}
+	matched, _ := path.Match(prefix, head)
+	return matched
+}
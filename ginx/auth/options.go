@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/3086953492/gokit/ginx/cookie"
+)
+
+// Options 认证中间件配置
+type Options struct {
+	// Sources 令牌来源，按顺序读取第一个非空值，默认仅读取 Authorization 头
+	Sources []TokenSource
+
+	// SkipPaths 跳过认证的路径，支持 path.Match 通配符（如 "/api/*/public"），
+	// 以 "/**" 结尾时匹配该前缀下的全部子路径（如 "/static/**"）
+	SkipPaths []string
+
+	// Optional 可选认证模式：未携带令牌时放行（匿名访问），携带了无效令牌仍返回 401
+	Optional bool
+
+	// Realm WWW-Authenticate 头中的 realm，默认空（不输出）
+	Realm string
+
+	// ErrorHandler 认证失败时的自定义处理，默认输出 401 Problem 响应。
+	// 调用前已设置 WWW-Authenticate 头，处理函数无需再调用 c.Abort。
+	ErrorHandler func(c *gin.Context, err error)
+
+	// Refresher 自动续期使用的令牌刷新器，为 nil 时不启用自动续期
+	Refresher Refresher
+
+	// Cookies 自动续期读取刷新令牌、写回新令牌使用的 Cookie 配置
+	Cookies *cookie.TokenCookies
+
+	// RefreshGrace 刷新结果宽限期，期内来自同一会话、携带同一旧刷新令牌的请求复用已换得的令牌对。
+	// 默认 DefaultRefreshGrace，为 0 时不缓存，仅合并同时进行的刷新
+	RefreshGrace time.Duration
+}
+
+// DefaultRefreshGrace 自动续期结果的默认宽限期
+const DefaultRefreshGrace = 5 * time.Second
+
+// defaultOptions 返回带有合理默认值的 Options
+func defaultOptions() *Options {
+	return &Options{
+		Sources:      []TokenSource{FromHeader()},
+		RefreshGrace: DefaultRefreshGrace,
+	}
+}
+
+// Option 配置函数类型
+type Option func(*Options)
+
+// WithTokenSources 设置令牌来源
+func WithTokenSources(sources ...TokenSource) Option {
+	return func(o *Options) {
+		if len(sources) > 0 {
+			o.Sources = sources
+		}
+	}
+}
+
+// WithSkipPaths 设置跳过认证的路径，可直接传入 config 中的 Middleware.Auth.SkipPaths
+func WithSkipPaths(paths ...string) Option {
+	return func(o *Options) {
+		o.SkipPaths = append(o.SkipPaths, paths...)
+	}
+}
+
+// WithOptional 设置可选认证模式
+func WithOptional(optional bool) Option {
+	return func(o *Options) {
+		o.Optional = optional
+	}
+}
+
+// WithRealm 设置 WWW-Authenticate 头中的 realm
+func WithRealm(realm string) Option {
+	return func(o *Options) {
+		o.Realm = realm
+	}
+}
+
+// WithErrorHandler 设置认证失败时的自定义处理
+func WithErrorHandler(fn func(c *gin.Context, err error)) Option {
+	return func(o *Options) {
+		o.ErrorHandler = fn
+	}
+}
+
+// WithAutoRefresh 启用自动续期：访问令牌过期时使用刷新令牌 Cookie 换取新令牌对，
+// 写回 Cookie 后继续处理当前请求，省去客户端额外的刷新往返。
+// 通常与 FromCookie 令牌来源配合使用，r 可直接传入 *jwt.Manager。
+//
+//	tc := cookie.New()
+//	r.Use(auth.Middleware(jwtMgr,
+//		auth.WithTokenSources(auth.FromCookie(tc)),
+//		auth.WithAutoRefresh(jwtMgr, tc),
+//	))
+func WithAutoRefresh(r Refresher, tc *cookie.TokenCookies) Option {
+	return func(o *Options) {
+		if r != nil && tc != nil {
+			o.Refresher = r
+			o.Cookies = tc
+		}
+	}
+}
+
+// WithRefreshGrace 设置自动续期结果的宽限期，默认 DefaultRefreshGrace，传入 0 关闭宽限缓存。
+// 刷新完成后，浏览器收到新 Cookie 前发出的并行请求仍携带旧刷新令牌；
+// 期内来自同一会话（同一过期访问令牌、客户端 IP 与 User-Agent）的此类请求复用已换得的令牌对，
+// 避免在启用轮换时被误判为刷新令牌重用。其余携带旧刷新令牌的请求照常触发重用检测。
+// 宽限期应尽量短（如数秒），期内被盗的旧刷新令牌若连同会话信息一并被重放，同样能取得新令牌对；
+// 关闭后启用轮换时，迟到的并行请求会触发重用检测并撤销整个家族，使用户被迫重新登录。
+// 负值被忽略。
+func WithRefreshGrace(d time.Duration) Option {
+	return func(o *Options) {
+		if d >= 0 {
+			o.RefreshGrace = d
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/3086953492/gokit/ginx/cookie"
+)
+
+// Refresher 使用刷新令牌换取新的令牌对，*jwt.Manager 直接实现了该接口。
+type Refresher interface {
+	RefreshTokenPair(ctx context.Context, refreshToken string) (accessToken, newRefreshToken string, err error)
+}
+
+// tokenPair 一次刷新得到的令牌对
+type tokenPair struct {
+	access  string
+	refresh string
+}
+
+// autoRefresher 访问令牌过期时使用刷新令牌 Cookie 自动续期。
+//
+// 同一会话携带同一刷新令牌的并发刷新通过 singleflight 合并为一次；
+// 配置了宽限期时，刷新结果在期内按会话与旧刷新令牌缓存，
+// 使浏览器尚未收到新 Cookie 时发出的并行请求复用同一结果。
+// 合并与缓存均以会话绑定：来自其他会话的旧刷新令牌照常交由 Refresher 处理并触发重用检测。
+// 宽限缓存仅在进程内生效，多实例部署时应将同一会话的请求路由到同一实例。
+type autoRefresher struct {
+	refresher Refresher
+	cookies   *cookie.TokenCookies
+	grace     time.Duration
+
+	group singleflight.Group
+
+	mu      sync.Mutex
+	results map[string]graceEntry
+}
+
+type graceEntry struct {
+	pair     tokenPair
+	expireAt time.Time
+}
+
+// newAutoRefresher 创建自动续期器
+func newAutoRefresher(r Refresher, tc *cookie.TokenCookies, grace time.Duration) *autoRefresher {
+	return &autoRefresher{
+		refresher: r,
+		cookies:   tc,
+		grace:     grace,
+		results:   make(map[string]graceEntry),
+	}
+}
+
+// refresh 读取刷新令牌 Cookie 并换取新令牌对，成功后写入新 Cookie。
+// expired 为请求携带的过期访问令牌，用于将合并与缓存绑定到会话。
+// 请求未携带刷新令牌时返回空字符串与 nil 错误。
+func (a *autoRefresher) refresh(c *gin.Context, expired string) (string, error) {
+	refreshToken, err := a.cookies.GetRefresh(c)
+	if err != nil || refreshToken == "" {
+		return "", nil
+	}
+
+	key := sessionKey(c, refreshToken, expired)
+
+	pair, ok := a.cached(key)
+	if !ok {
+		v, err, _ := a.group.Do(key, func() (any, error) {
+			if pair, ok := a.cached(key); ok {
+				return pair, nil
+			}
+			// 刷新不受单个请求取消影响，结果会被并发请求共享
+			ctx := context.WithoutCancel(c.Request.Context())
+			access, refresh, err := a.refresher.RefreshTokenPair(ctx, refreshToken)
+			if err != nil {
+				return nil, err
+			}
+			pair := tokenPair{access: access, refresh: refresh}
+			a.store(key, pair)
+			return pair, nil
+		})
+		if err != nil {
+			return "", err
+		}
+		pair = v.(tokenPair)
+	}
+
+	a.cookies.SetAccess(c, pair.access)
+	a.cookies.SetRefresh(c, pair.refresh)
+	return pair.access, nil
+}
+
+// sessionKey 以刷新令牌、过期访问令牌、客户端 IP 与 User-Agent 计算会话绑定的 key
+func sessionKey(c *gin.Context, refreshToken, expired string) string {
+	h := sha256.New()
+	for _, part := range []string{refreshToken, expired, c.ClientIP(), c.Request.UserAgent()} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// cached 返回宽限期内的刷新结果
+func (a *autoRefresher) cached(key string) (tokenPair, bool) {
+	if a.grace <= 0 {
+		return tokenPair{}, false
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	e, ok := a.results[key]
+	if !ok || time.Now().After(e.expireAt) {
+		return tokenPair{}, false
+	}
+	return e.pair, true
+}
+
+// store 缓存刷新结果并清理过期记录，未配置宽限期时不缓存
+func (a *autoRefresher) store(key string, pair tokenPair) {
+	if a.grace <= 0 {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := time.Now()
+	for k, e := range a.results {
+		if now.After(e.expireAt) {
+			delete(a.results, k)
+		}
+	}
+	a.results[key] = graceEntry{pair: pair, expireAt: now.Add(a.grace)}
+}
+
+// clear 刷新失败且属于认证错误时清除令牌 Cookie，促使客户端重新登录
+func (a *autoRefresher) clear(c *gin.Context, err error) {
+	if status, _ := classify(err); status == http.StatusUnauthorized {
+		a.cookies.Clear(c)
+	}
+}
@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/3086953492/gokit/ginx/cookie"
+)
+
+// FromHeader 从 Authorization 头读取 Bearer 令牌（RFC 6750 §2.1），scheme 不区分大小写
+func FromHeader() TokenSource {
+	return func(c *gin.Context) string {
+		scheme, token, ok := strings.Cut(c.GetHeader("Authorization"), " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") {
+			return ""
+		}
+		return strings.TrimSpace(token)
+	}
+}
+
+// FromCookie 从 ginx/cookie 管理的访问令牌 Cookie 读取令牌
+func FromCookie(tc *cookie.TokenCookies) TokenSource {
+	return func(c *gin.Context) string {
+		token, err := tc.GetAccess(c)
+		if err != nil {
+			return ""
+		}
+		return token
+	}
+}
+
+// FromQuery 从查询参数读取令牌（RFC 6750 §2.3），令牌会出现在访问日志中，仅建议用于 WebSocket 等无法设置请求头的场景
+func FromQuery(name string) TokenSource {
+	return func(c *gin.Context) string {
+		return c.Query(name)
+	}
+}
@@ -0,0 +1,54 @@
+// Package auth 提供基于 jwt.Manager 的 gin 认证中间件。
+//
+// 中间件按配置的来源（Authorization 头、Cookie、查询参数）依次读取访问令牌，
+// 验证通过后将 Claims 写入 gin.Context；失败时返回 401 Problem 响应，
+// 并按 RFC 6750 设置 WWW-Authenticate 头。
+//
+//	tc := cookie.New()
+//	r.Use(auth.Middleware(jwtMgr,
+//		auth.WithTokenSources(auth.FromHeader(), auth.FromCookie(tc)),
+//		auth.WithSkipPaths(cfg.Middleware.Auth.SkipPaths...),
+//	))
+//
+//	r.GET("/me", func(c *gin.Context) {
+//		userID := auth.Subject(c)
+//	})
+//
+// 启用 WithAutoRefresh 后，访问令牌过期时中间件使用刷新令牌 Cookie 自动续期。
+// 续期调用 RefreshTokenPair 而非 RefreshAccessToken：同时换取新的刷新令牌并写回 Cookie，
+// 使自动续期在启用刷新令牌轮换（jwt.WithRefreshRotation）时同样可用；
+// 未启用轮换时旧刷新令牌在过期前仍然有效，行为与仅刷新访问令牌一致。
+//
+// 启用轮换时，刷新完成后浏览器尚未应用新 Cookie 的并行请求仍携带旧刷新令牌，
+// 直接交由 RefreshTokenPair 会被判定为重用并撤销整个家族。为此中间件默认启用
+// DefaultRefreshGrace 的宽限缓存：期内同一会话携带同一旧刷新令牌的请求复用已换得的令牌对。
+// 代价是宽限期内连同会话信息（过期访问令牌、客户端 IP 与 User-Agent）一并被重放的旧刷新令牌
+// 同样能取得新令牌对；可通过 WithRefreshGrace 缩短或以 0 关闭，关闭后迟到的并行请求会使用户被迫重新登录。
+// 宽限缓存仅在进程内生效，多实例部署时应将同一会话的请求路由到同一实例。
+package auth
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/3086953492/gokit/jwt"
+)
+
+// Verifier 访问令牌验证接口，*jwt.Manager 直接实现了该接口
+type Verifier interface {
+	ParseAccessTokenContext(ctx context.Context, tokenString string) (*jwt.Claims, error)
+}
+
+// VerifierFunc 是 Verifier 的函数适配器，可用于适配 jwt.RemoteVerifier：
+//
+//	auth.Middleware(auth.VerifierFunc(remote.Verify))
+type VerifierFunc func(ctx context.Context, tokenString string) (*jwt.Claims, error)
+
+// ParseAccessTokenContext 实现 Verifier 接口
+func (f VerifierFunc) ParseAccessTokenContext(ctx context.Context, tokenString string) (*jwt.Claims, error) {
+	return f(ctx, tokenString)
+}
+
+// TokenSource 从请求中读取访问令牌，未找到时返回空字符串
+type TokenSource func(c *gin.Context) string
@@ -0,0 +1,194 @@
+package authz
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/3086953492/gokit/ginx/auth"
+	"github.com/3086953492/gokit/ginx/problem"
+)
+
+// principalKey 授权主体在 gin.Context 中的缓存键
+const principalKey = "gokit.authz.principal"
+
+// Authorizer 持有角色继承与权限配置，并生成路由守卫。Authorizer 创建后只读，可在多个路由间共享。
+type Authorizer struct {
+	opts *Options
+}
+
+// New 创建 Authorizer
+func New(opts ...Option) *Authorizer {
+	o := defaultOptions()
+	for _, fn := range opts {
+		fn(o)
+	}
+	o.RoleHierarchy = cloneMap(o.RoleHierarchy)
+	o.RolePermissions = cloneMap(o.RolePermissions)
+	return &Authorizer{opts: o}
+}
+
+// Principal 返回当前请求的授权主体，未认证时返回 (nil, false)。
+// 结果在同一请求内缓存，处理函数中可直接用于细粒度判断：
+//
+//	p, _ := az.Principal(c)
+//	if p.HasPermission("order:export") { ... }
+func (a *Authorizer) Principal(c *gin.Context) (*Principal, bool) {
+	if v, ok := c.Get(principalKey); ok {
+		if p, ok := v.(*Principal); ok && p.owner == a {
+			return p, true
+		}
+	}
+
+	claims, ok := auth.Claims(c)
+	if !ok {
+		return nil, false
+	}
+
+	p := &Principal{
+		Subject: claims.Subject,
+		Claims:  claims,
+		scopes:  make(map[string]bool),
+		roles:   a.expandRoles(claimStrings(claims.Extra, a.opts.RoleClaim)),
+		owner:   a,
+	}
+	for _, s := range strings.Fields(claims.Scope) {
+		p.scopes[s] = true
+	}
+	for _, s := range claimStrings(claims.Extra, a.opts.ScopeClaim) {
+		p.scopes[s] = true
+	}
+
+	permissions := make(map[string]bool)
+	for _, perm := range claimStrings(claims.Extra, a.opts.PermissionClaim) {
+		permissions[perm] = true
+	}
+	for role := range p.roles {
+		for _, perm := range a.opts.RolePermissions[role] {
+			permissions[perm] = true
+		}
+	}
+	p.permissions = sortedKeys(permissions)
+
+	c.Set(principalKey, p)
+	return p, true
+}
+
+// RequireScopes 要求访问令牌包含全部指定 scope。
+// 不满足时返回 403，并按 RFC 6750 §3.1 设置 insufficient_scope 的 WWW-Authenticate 头。
+func (a *Authorizer) RequireScopes(scopes ...string) gin.HandlerFunc {
+	challenge := `Bearer error="insufficient_scope", scope="` + strings.Join(scopes, " ") + `"`
+	return a.guard(func(c *gin.Context, p *Principal) error {
+		for _, s := range scopes {
+			if !p.HasScope(s) {
+				c.Header("WWW-Authenticate", challenge)
+				return ErrInsufficientScope
+			}
+		}
+		return nil
+	})
+}
+
+// RequireAnyRole 要求主体至少拥有其中一个角色（含继承得到的角色）
+func (a *Authorizer) RequireAnyRole(roles ...string) gin.HandlerFunc {
+	return a.guard(func(c *gin.Context, p *Principal) error {
+		if slices.ContainsFunc(roles, p.HasRole) {
+			return nil
+		}
+		return ErrForbidden
+	})
+}
+
+// RequireAllPermissions 要求主体拥有全部指定权限
+func (a *Authorizer) RequireAllPermissions(permissions ...string) gin.HandlerFunc {
+	return a.guard(func(c *gin.Context, p *Principal) error {
+		for _, perm := range permissions {
+			if !p.HasPermission(perm) {
+				return ErrForbidden
+			}
+		}
+		return nil
+	})
+}
+
+// Require 要求主体通过全部授权策略，用于资源级（ABAC）检查
+func (a *Authorizer) Require(policies ...Policy) gin.HandlerFunc {
+	return a.guard(func(c *gin.Context, p *Principal) error {
+		for _, policy := range policies {
+			allowed, err := policy(c, p)
+			if err != nil {
+				return err
+			}
+			if !allowed {
+				return ErrForbidden
+			}
+		}
+		return nil
+	})
+}
+
+// guard 将检查函数包装为中间件
+func (a *Authorizer) guard(check func(c *gin.Context, p *Principal) error) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		p, ok := a.Principal(c)
+		if !ok {
+			c.Header("WWW-Authenticate", "Bearer")
+			a.fail(c, ErrUnauthenticated)
+			return
+		}
+		if err := check(c, p); err != nil {
+			a.fail(c, err)
+			return
+		}
+		c.Next()
+	}
+}
+
+// fail 输出授权失败响应
+func (a *Authorizer) fail(c *gin.Context, err error) {
+	if a.opts.ErrorHandler != nil {
+		a.opts.ErrorHandler(c, err)
+		c.Abort()
+		return
+	}
+
+	switch {
+	case errors.Is(err, ErrUnauthenticated):
+		problem.Fail(c, http.StatusUnauthorized, "Unauthorized", "authentication required", "")
+	case errors.Is(err, ErrInsufficientScope):
+		problem.Fail(c, http.StatusForbidden, "Forbidden", "insufficient scope", "")
+	case errors.Is(err, ErrForbidden):
+		problem.Fail(c, http.StatusForbidden, "Forbidden", "insufficient permissions", "")
+	default:
+		problem.Fail(c, http.StatusInternalServerError, "Internal Server Error", "authorization unavailable", "")
+	}
+	c.Abort()
+}
+
+// expandRoles 按继承关系展开角色，继承关系中存在环时同样能正常结束
+func (a *Authorizer) expandRoles(roles []string) map[string]bool {
+	expanded := make(map[string]bool, len(roles))
+	queue := slices.Clone(roles)
+	for len(queue) > 0 {
+		role := queue[0]
+		queue = queue[1:]
+		if expanded[role] {
+			continue
+		}
+		expanded[role] = true
+		queue = append(queue, a.opts.RoleHierarchy[role]...)
+	}
+	return expanded
+}
+
+// cloneMap 复制配置，避免调用方之后修改影响已创建的 Authorizer
+func cloneMap(m map[string][]string) map[string][]string {
+	out := make(map[string][]string, len(m))
+	for k, v := range m {
+		out[k] = slices.Clone(v)
+	}
+	return out
+}
@@ -0,0 +1,14 @@
+package authz
+
+import "errors"
+
+var (
+	// ErrUnauthenticated 表示请求未通过认证，通常是未注册 auth.Middleware 或处于可选认证的匿名请求
+	ErrUnauthenticated = errors.New("authz: unauthenticated")
+
+	// ErrInsufficientScope 表示访问令牌缺少所需的 scope
+	ErrInsufficientScope = errors.New("authz: insufficient scope")
+
+	// ErrForbidden 表示主体缺少所需的角色、权限或未通过授权策略
+	ErrForbidden = errors.New("authz: forbidden")
+)
@@ -0,0 +1,88 @@
+package authz
+
+import "github.com/gin-gonic/gin"
+
+// Options 授权配置
+type Options struct {
+	// ScopeClaim 存放 scope 的扩展声明名，值可以是空格分隔的字符串或字符串数组，默认 "scope"。
+	// OAuth 授权签发的令牌中的 scope 声明（Claims.Scope）始终会被读取
+	ScopeClaim string
+
+	// RoleClaim 存放角色的扩展声明名，默认 "roles"
+	RoleClaim string
+
+	// PermissionClaim 存放权限的扩展声明名，默认 "permissions"
+	PermissionClaim string
+
+	// RoleHierarchy 角色继承关系：角色 -> 直接继承的角色，支持多级继承
+	RoleHierarchy map[string][]string
+
+	// RolePermissions 角色 -> 权限，主体拥有其全部角色（含继承角色）对应的权限
+	RolePermissions map[string][]string
+
+	// ErrorHandler 授权失败时的自定义处理，默认输出 Problem 响应，处理函数无需再调用 c.Abort。
+	// err 可用 errors.Is 与 ErrUnauthenticated、ErrInsufficientScope、ErrForbidden 比较，
+	// 其余错误来自 Policy。
+	ErrorHandler func(c *gin.Context, err error)
+}
+
+// defaultOptions 返回带有合理默认值的 Options
+func defaultOptions() *Options {
+	return &Options{
+		ScopeClaim:      "scope",
+		RoleClaim:       "roles",
+		PermissionClaim: "permissions",
+	}
+}
+
+// Option 配置函数类型
+type Option func(*Options)
+
+// WithScopeClaim 设置存放 scope 的扩展声明名
+func WithScopeClaim(name string) Option {
+	return func(o *Options) {
+		if name != "" {
+			o.ScopeClaim = name
+		}
+	}
+}
+
+// WithRoleClaim 设置存放角色的扩展声明名
+func WithRoleClaim(name string) Option {
+	return func(o *Options) {
+		if name != "" {
+			o.RoleClaim = name
+		}
+	}
+}
+
+// WithPermissionClaim 设置存放权限的扩展声明名
+func WithPermissionClaim(name string) Option {
+	return func(o *Options) {
+		if name != "" {
+			o.PermissionClaim = name
+		}
+	}
+}
+
+// WithRoleHierarchy 设置角色继承关系，如 {"admin": {"editor"}, "editor": {"viewer"}}
+// 表示 admin 同时拥有 editor 与 viewer 角色
+func WithRoleHierarchy(hierarchy map[string][]string) Option {
+	return func(o *Options) {
+		o.RoleHierarchy = hierarchy
+	}
+}
+
+// WithRolePermissions 设置角色对应的权限，权限可使用通配符
+func WithRolePermissions(permissions map[string][]string) Option {
+	return func(o *Options) {
+		o.RolePermissions = permissions
+	}
+}
+
+// WithErrorHandler 设置授权失败时的自定义处理
+func WithErrorHandler(fn func(c *gin.Context, err error)) Option {
+	return func(o *Options) {
+		o.ErrorHandler = fn
+	}
+}
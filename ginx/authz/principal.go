@@ -0,0 +1,114 @@
+package authz
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/3086953492/gokit/jwt"
+)
+
+// Principal 当前请求的授权主体，由 Claims 与授权配置计算得出
+type Principal struct {
+	// Subject 用户标识
+	Subject string
+
+	// Claims 已验证的访问令牌声明
+	Claims *jwt.Claims
+
+	scopes      map[string]bool
+	roles       map[string]bool
+	permissions []string
+
+	owner *Authorizer
+}
+
+// HasScope 返回访问令牌是否包含指定 scope
+func (p *Principal) HasScope(scope string) bool {
+	return p.scopes[scope]
+}
+
+// HasRole 返回主体是否拥有指定角色（含继承得到的角色）
+func (p *Principal) HasRole(role string) bool {
+	return p.roles[role]
+}
+
+// HasPermission 返回主体是否拥有指定权限，已授予的通配符权限（如 "order:*"）参与匹配
+func (p *Principal) HasPermission(permission string) bool {
+	for _, granted := range p.permissions {
+		if matchPermission(granted, permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// Scopes 返回访问令牌包含的全部 scope（已排序）
+func (p *Principal) Scopes() []string {
+	return sortedKeys(p.scopes)
+}
+
+// Roles 返回主体拥有的全部角色（含继承得到的角色，已排序）
+func (p *Principal) Roles() []string {
+	return sortedKeys(p.roles)
+}
+
+// Permissions 返回主体被授予的全部权限，包括声明中的权限与角色对应的权限（已排序）
+func (p *Principal) Permissions() []string {
+	return slices.Clone(p.permissions)
+}
+
+// matchPermission 判断已授予的权限是否覆盖所需权限。
+// 权限以 ":" 分段，"*" 匹配任意单个分段；位于末尾时匹配其后的全部分段，
+// 因此 "order:*" 覆盖 "order:read" 与 "order:item:delete"，"*" 覆盖一切权限。
+func matchPermission(granted, required string) bool {
+	if granted == required || granted == "*" {
+		return true
+	}
+
+	g := strings.Split(granted, ":")
+	r := strings.Split(required, ":")
+	for i, part := range g {
+		if i >= len(r) {
+			return false
+		}
+		if part != "*" {
+			if part != r[i] {
+				return false
+			}
+			continue
+		}
+		if i == len(g)-1 {
+			return true
+		}
+	}
+	return len(g) == len(r)
+}
+
+// claimStrings 读取字符串数组或空格分隔字符串形式的扩展声明
+func claimStrings(extra map[string]any, name string) []string {
+	switch v := extra[name].(type) {
+	case string:
+		return strings.Fields(v)
+	case []string:
+		return v
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok && s != "" {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// sortedKeys 返回集合中的元素（已排序）
+func sortedKeys(set map[string]bool) []string {
+	out := make([]string, 0, len(set))
+	for k := range set {
+		out = append(out, k)
+	}
+	slices.Sort(out)
+	return out
+}
@@ -0,0 +1,27 @@
+// Package authz 提供基于 jwt 声明的 gin 授权中间件。
+//
+// 授权在认证之后进行：auth.Middleware 验证访问令牌并写入 Claims，
+// authz 的路由守卫从 Claims 读取 scope、角色与权限，不满足时返回 403 Problem 响应。
+// 角色支持继承（如 admin 继承 editor），权限支持通配符（如 "order:*"），
+// 资源级（ABAC）检查通过 Policy 函数实现。
+//
+//	az := authz.New(
+//		authz.WithRoleHierarchy(map[string][]string{"admin": {"editor"}, "editor": {"viewer"}}),
+//	)
+//
+//	api := r.Group("/api", auth.Middleware(jwtMgr))
+//	api.GET("/orders", az.RequireAllPermissions("order:read"), listOrders)
+//	api.DELETE("/orders/:id", az.RequireAnyRole("admin"), deleteOrder)
+//	api.PUT("/orders/:id", az.Require(func(c *gin.Context, p *authz.Principal) (bool, error) {
+//		order, err := orders.Get(c.Request.Context(), c.Param("id"))
+//		if err != nil {
+//			return false, err
+//		}
+//		return order.OwnerID == p.Subject || p.HasPermission("order:manage"), nil
+//	}), updateOrder)
+package authz
+
+import "github.com/gin-gonic/gin"
+
+// Policy 授权策略函数，返回 false 时拒绝访问（403），返回错误时视为授权不可用（500）。
+type Policy func(c *gin.Context, p *Principal) (bool, error)
@@ -0,0 +1,11 @@
+package idempotency
+
+import "errors"
+
+var (
+	// ErrNilStore 表示 Store 为 nil
+	ErrNilStore = errors.New("idempotency: store is nil")
+
+	// ErrNotOwner 表示处理中记录已过期并被其他请求占用，当前请求不能再完成或释放该 key
+	ErrNotOwner = errors.New("idempotency: key is held by another request")
+)
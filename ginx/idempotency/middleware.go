@@ -0,0 +1,188 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/3086953492/gokit/ginx/problem"
+)
+
+// Middleware 返回幂等处理中间件。
+//
+//	store := idempotency.NewRedisStore(redisMgr)
+//	r.POST("/orders", idempotency.Middleware(store), createOrder)
+//
+// 若 store 为 nil 会 panic，应在路由注册阶段暴露配置错误。
+func Middleware(store Store, opts ...Option) gin.HandlerFunc {
+	if store == nil {
+		panic(ErrNilStore)
+	}
+
+	o := defaultOptions()
+	for _, fn := range opts {
+		fn(o)
+	}
+
+	return func(c *gin.Context) {
+		if !slices.Contains(o.Methods, c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		key := c.GetHeader(o.Header)
+		if key == "" {
+			if o.Required {
+				problem.Fail(c, http.StatusBadRequest, "Bad Request", "missing "+o.Header+" header", "")
+				c.Abort()
+				return
+			}
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, o.MaxBodySize))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				problem.Fail(c, http.StatusRequestEntityTooLarge, "Request Entity Too Large",
+					fmt.Sprintf("request body exceeds %d bytes", tooLarge.Limit), "")
+			} else {
+				problem.Fail(c, http.StatusBadRequest, "Bad Request", "read request body failed", "")
+			}
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		if o.Scope != nil {
+			key = o.Scope(c, key)
+		}
+		storeKey := o.KeyPrefix + key
+		fingerprint := fingerprintOf(c.Request, body)
+
+		// 存储操作不受客户端断开影响，避免记录停留在处理中状态
+		ctx := context.WithoutCancel(c.Request.Context())
+
+		owner := uuid.NewString()
+		rec, err := store.Begin(ctx, storeKey, owner, fingerprint, o.InFlightTTL)
+		if err != nil {
+			problem.Fail(c, http.StatusInternalServerError, "Internal Server Error", "idempotency store unavailable", "")
+			c.Abort()
+			return
+		}
+
+		if rec != nil {
+			switch {
+			case rec.Fingerprint != fingerprint:
+				problem.Fail(c, http.StatusUnprocessableEntity, "Unprocessable Entity",
+					o.Header+" has already been used with a different request", "")
+				c.Abort()
+			case !rec.Completed:
+				problem.Fail(c, http.StatusConflict, "Conflict",
+					"a request with the same "+o.Header+" is being processed", "")
+				c.Abort()
+			default:
+				replay(c, rec)
+			}
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer, limit: o.MaxResponseSize}
+		c.Writer = recorder
+
+		settled := false
+		defer func() {
+			if !settled {
+				// handler panic，释放 key 允许重试
+				_ = store.Release(ctx, storeKey, owner)
+			}
+		}()
+
+		c.Next()
+
+		// 5xx 允许重试；响应体过大时不保存，避免占用过多内存与存储
+		status := c.Writer.Status()
+		if status >= http.StatusInternalServerError || recorder.overflow {
+			settled = true
+			_ = store.Release(ctx, storeKey, owner)
+			return
+		}
+
+		// key 已被其他请求占用时 Complete 返回 ErrNotOwner，不覆盖对方的记录
+		_ = store.Complete(ctx, storeKey, owner, &Record{
+			Fingerprint: fingerprint,
+			Completed:   true,
+			Status:      status,
+			Header:      c.Writer.Header().Clone(),
+			Body:        recorder.body.Bytes(),
+		}, o.TTL)
+		settled = true
+	}
+}
+
+// replay 重放已保存的响应并终止后续 handler
+func replay(c *gin.Context, rec *Record) {
+	header := c.Writer.Header()
+	for k, v := range rec.Header {
+		header[k] = slices.Clone(v)
+	}
+	header.Set(ReplayedHeader, "true")
+
+	c.Writer.WriteHeader(rec.Status)
+	_, _ = c.Writer.Write(rec.Body)
+	c.Abort()
+}
+
+// fingerprintOf 计算请求指纹：SHA-256(方法 + 路径 + 请求体)
+func fingerprintOf(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method))
+	h.Write([]byte{' '})
+	h.Write([]byte(r.URL.Path))
+	h.Write([]byte{'\n'})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder 在写出响应的同时保留响应体副本，超过 limit 后停止保留
+type responseRecorder struct {
+	gin.ResponseWriter
+	body     bytes.Buffer
+	limit    int64
+	overflow bool
+}
+
+// Write 实现 io.Writer
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.keep(data)
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString 实现 io.StringWriter
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.keep([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+// keep 保留响应体副本，超出上限时丢弃已保留的内容
+func (w *responseRecorder) keep(data []byte) {
+	if w.overflow {
+		return
+	}
+	if int64(w.body.Len()+len(data)) > w.limit {
+		w.overflow = true
+		w.body = bytes.Buffer{}
+		return
+	}
+	w.body.Write(data)
+}
@@ -0,0 +1,125 @@
+package idempotency
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultHeader 默认幂等键请求头
+const DefaultHeader = "Idempotency-Key"
+
+// ReplayedHeader 重放响应时附加的响应头
+const ReplayedHeader = "Idempotent-Replayed"
+
+// DefaultMaxBodySize 默认请求体与响应体大小上限（1 MiB）
+const DefaultMaxBodySize = 1 << 20
+
+// Options 中间件配置
+type Options struct {
+	Header      string                                  // 幂等键请求头，默认 "Idempotency-Key"
+	KeyPrefix   string                                  // 存储 key 前缀，默认 "idempotency:"
+	Methods     []string                                // 启用幂等处理的请求方法，默认 POST、PATCH
+	TTL         time.Duration                           // 完成记录的重放窗口，默认 24 小时
+	InFlightTTL time.Duration                           // 处理中状态的最长保留时间，默认 1 分钟
+	Required    bool                                    // 是否要求必须携带幂等键，默认 false
+	Scope       func(c *gin.Context, key string) string // 幂等键作用域，如按用户隔离，默认原样使用
+
+	MaxBodySize     int64 // 携带幂等键的请求体大小上限，超出时返回 413，默认 1 MiB
+	MaxResponseSize int64 // 可保存的响应体大小上限，超出时不保存并释放 key，默认 1 MiB
+}
+
+func defaultOptions() *Options {
+	return &Options{
+		Header:      DefaultHeader,
+		KeyPrefix:   "idempotency:",
+		Methods:     []string{http.MethodPost, http.MethodPatch},
+		TTL:         24 * time.Hour,
+		InFlightTTL: time.Minute,
+
+		MaxBodySize:     DefaultMaxBodySize,
+		MaxResponseSize: DefaultMaxBodySize,
+	}
+}
+
+// Option 中间件选项函数
+type Option func(*Options)
+
+// WithHeader 设置幂等键请求头
+func WithHeader(name string) Option {
+	return func(o *Options) {
+		if name != "" {
+			o.Header = name
+		}
+	}
+}
+
+// WithKeyPrefix 设置存储 key 前缀
+func WithKeyPrefix(prefix string) Option {
+	return func(o *Options) {
+		o.KeyPrefix = prefix
+	}
+}
+
+// WithMethods 设置启用幂等处理的请求方法
+func WithMethods(methods ...string) Option {
+	return func(o *Options) {
+		if len(methods) > 0 {
+			o.Methods = methods
+		}
+	}
+}
+
+// WithTTL 设置完成记录的重放窗口
+func WithTTL(ttl time.Duration) Option {
+	return func(o *Options) {
+		if ttl > 0 {
+			o.TTL = ttl
+		}
+	}
+}
+
+// WithInFlightTTL 设置处理中状态的最长保留时间
+// 应大于 handler 的最长处理时间，进程崩溃后 key 最迟在此时间后释放
+func WithInFlightTTL(ttl time.Duration) Option {
+	return func(o *Options) {
+		if ttl > 0 {
+			o.InFlightTTL = ttl
+		}
+	}
+}
+
+// WithRequired 设置是否要求必须携带幂等键，缺失时返回 400
+func WithRequired(required bool) Option {
+	return func(o *Options) {
+		o.Required = required
+	}
+}
+
+// WithScope 设置幂等键作用域函数
+// 例如按登录用户隔离，避免不同用户之间的 key 冲突
+func WithScope(fn func(c *gin.Context, key string) string) Option {
+	return func(o *Options) {
+		o.Scope = fn
+	}
+}
+
+// WithMaxBodySize 设置携带幂等键的请求体大小上限，超出时返回 413
+func WithMaxBodySize(n int64) Option {
+	return func(o *Options) {
+		if n > 0 {
+			o.MaxBodySize = n
+		}
+	}
+}
+
+// WithMaxResponseSize 设置可保存的响应体大小上限
+// 响应体超出上限时照常写出但不保存，并释放 key，重试请求会再次执行 handler
+func WithMaxResponseSize(n int64) Option {
+	return func(o *Options) {
+		if n > 0 {
+			o.MaxResponseSize = n
+		}
+	}
+}
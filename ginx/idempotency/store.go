@@ -0,0 +1,101 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/3086953492/gokit/redis"
+)
+
+var _ Store = (*RedisStore)(nil)
+
+// beginScript key 不存在时写入处理中记录并返回 nil，否则返回已有记录
+var beginScript = redis.NewScript(`
+	if redis.call("set", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+		return false
+	end
+	return redis.call("get", KEYS[1])
+`)
+
+// completeScript 仅当 key 仍为 owner 的处理中记录时写入完成记录
+// KEYS[1] 幂等 key，ARGV[1] owner，ARGV[2] 完成记录，ARGV[3] 有效期毫秒数
+var completeScript = redis.NewScript(`
+	local current = redis.call("get", KEYS[1])
+	if current and cjson.decode(current).owner == ARGV[1] then
+		redis.call("set", KEYS[1], ARGV[2], "PX", ARGV[3])
+		return 1
+	end
+	return 0
+`)
+
+// releaseScript 仅当 key 仍为 owner 的处理中记录时删除
+// KEYS[1] 幂等 key，ARGV[1] owner
+var releaseScript = redis.NewScript(`
+	local current = redis.call("get", KEYS[1])
+	if current and cjson.decode(current).owner == ARGV[1] then
+		return redis.call("del", KEYS[1])
+	end
+	return 0
+`)
+
+// RedisStore 基于 redis.Manager 的幂等记录存储
+type RedisStore struct {
+	mgr *redis.Manager
+}
+
+// NewRedisStore 创建 Redis 幂等记录存储
+func NewRedisStore(mgr *redis.Manager) *RedisStore {
+	return &RedisStore{mgr: mgr}
+}
+
+// Begin 实现 Store 接口，使用 Lua 脚本保证占用与读取的原子性
+func (s *RedisStore) Begin(ctx context.Context, key, owner, fingerprint string, ttl time.Duration) (*Record, error) {
+	data, err := json.Marshal(&Record{Fingerprint: fingerprint, Owner: owner})
+	if err != nil {
+		return nil, fmt.Errorf("idempotency marshal: %w", err)
+	}
+
+	result, err := s.mgr.EvalScript(ctx, beginScript, []string{key}, data, ttl.Milliseconds())
+	if err != nil {
+		return nil, err
+	}
+	existing, ok := result.(string)
+	if !ok {
+		return nil, nil
+	}
+
+	var rec Record
+	if err := json.Unmarshal([]byte(existing), &rec); err != nil {
+		return nil, fmt.Errorf("idempotency unmarshal: %w", err)
+	}
+	return &rec, nil
+}
+
+// Complete 实现 Store 接口，使用 Lua 脚本比较 owner 后原子替换
+func (s *RedisStore) Complete(ctx context.Context, key, owner string, rec *Record, ttl time.Duration) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("idempotency marshal: %w", err)
+	}
+	result, err := s.mgr.EvalScript(ctx, completeScript, []string{key}, owner, data, ttl.Milliseconds())
+	return ownerResult(result, err)
+}
+
+// Release 实现 Store 接口，使用 Lua 脚本比较 owner 后删除
+func (s *RedisStore) Release(ctx context.Context, key, owner string) error {
+	result, err := s.mgr.EvalScript(ctx, releaseScript, []string{key}, owner)
+	return ownerResult(result, err)
+}
+
+// ownerResult 将脚本返回值转换为错误，返回 0 表示 key 已不由 owner 占用
+func ownerResult(result any, err error) error {
+	if err != nil {
+		return err
+	}
+	if n, _ := result.(int64); n != 1 {
+		return ErrNotOwner
+	}
+	return nil
+}
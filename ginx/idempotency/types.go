@@ -0,0 +1,51 @@
+// Package idempotency 提供基于 Idempotency-Key 请求头的幂等请求处理。
+//
+// 首次请求会占用 key 并在处理完成后保存响应（状态码、响应头、响应体），
+// 之后携带相同 key 的重试请求直接重放已保存的响应：
+//   - 首次请求仍在处理中时，重复请求返回 409 Conflict；
+//   - 同一 key 携带不同请求体时，返回 422 Unprocessable Entity；
+//   - 处理结果为 5xx 或 handler panic 时释放 key，允许客户端重试；
+//   - 请求体超过 MaxBodySize 时返回 413，响应体超过 MaxResponseSize 时不保存并释放 key。
+package idempotency
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Record 幂等记录
+type Record struct {
+	// Fingerprint 请求指纹（方法、路径与请求体的 SHA-256）
+	Fingerprint string `json:"fingerprint"`
+
+	// Owner 占用 key 的请求标识，完成与释放时据此确认 key 仍由该请求持有
+	Owner string `json:"owner,omitempty"`
+
+	// Completed 是否已处理完成；false 表示首次请求仍在处理中
+	Completed bool `json:"completed"`
+
+	// Status 响应状态码
+	Status int `json:"status,omitempty"`
+
+	// Header 响应头
+	Header http.Header `json:"header,omitempty"`
+
+	// Body 响应体
+	Body []byte `json:"body,omitempty"`
+}
+
+// Store 幂等记录存储接口。
+// owner 为占用 key 的请求标识：handler 处理时间超过处理中状态的保留时间后，
+// key 可能已被其他请求重新占用，此时 Complete 与 Release 必须不做修改并返回 ErrNotOwner。
+type Store interface {
+	// Begin 以处理中状态原子占用 key，ttl 为处理中状态的最长保留时间。
+	// 占用成功返回 (nil, nil)；key 已存在时返回已有记录。
+	Begin(ctx context.Context, key, owner, fingerprint string, ttl time.Duration) (*Record, error)
+
+	// Complete 在 key 仍由 owner 占用时保存处理完成的记录，ttl 为重放窗口
+	Complete(ctx context.Context, key, owner string, rec *Record, ttl time.Duration) error
+
+	// Release 在 key 仍由 owner 占用时释放 key，允许后续请求重新处理
+	Release(ctx context.Context, key, owner string) error
+}
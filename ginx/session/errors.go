@@ -0,0 +1,8 @@
+package session
+
+import "errors"
+
+var (
+	// ErrNilStore 表示 Store 为 nil
+	ErrNilStore = errors.New("session: store is nil")
+)
@@ -0,0 +1,187 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/3086953492/gokit/ginx/problem"
+)
+
+// contextKey 会话在 gin.Context 中的存储键
+const contextKey = "gokit.session"
+
+// From 返回当前请求的会话，未注册 Middleware 时返回 nil
+func From(c *gin.Context) *Session {
+	v, ok := c.Get(contextKey)
+	if !ok {
+		return nil
+	}
+	s, _ := v.(*Session)
+	return s
+}
+
+// Middleware 返回会话中间件。
+// 请求进入时根据 Cookie 加载会话，不存在或已过期时创建新会话；
+// 响应头写出前自动保存会话并刷新过期时间与 Cookie。
+// 未写入任何数据的新会话不会被持久化，也不会下发 Cookie。
+//
+// 若 store 为 nil 会 panic，应在路由注册阶段暴露配置错误。
+func Middleware(store Store, opts ...Option) gin.HandlerFunc {
+	if store == nil {
+		panic(ErrNilStore)
+	}
+
+	o := defaultOptions()
+	for _, fn := range opts {
+		fn(o)
+	}
+
+	return func(c *gin.Context) {
+		// 存储操作不受客户端断开影响，避免会话状态写入一半
+		ctx := context.WithoutCancel(c.Request.Context())
+
+		s, err := load(ctx, c, store, o)
+		if err != nil {
+			problem.Fail(c, http.StatusInternalServerError, "Internal Server Error", "session store unavailable", "")
+			c.Abort()
+			return
+		}
+		c.Set(contextKey, s)
+
+		w := &sessionWriter{ResponseWriter: c.Writer}
+		w.save = func() {
+			if err := save(ctx, c, store, o, s); err != nil && o.ErrorHandler != nil {
+				o.ErrorHandler(c, err)
+			}
+		}
+		c.Writer = w
+
+		c.Next()
+		w.commit()
+	}
+}
+
+// load 根据 Cookie 加载会话，无有效会话时创建新会话
+func load(ctx context.Context, c *gin.Context, store Store, o *Options) (*Session, error) {
+	id, err := c.Cookie(o.CookieName)
+	if err != nil || id == "" {
+		return newSession(o.IDLength)
+	}
+
+	data, err := store.Load(ctx, o.KeyPrefix+id)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		// 会话已过期或 ID 无效，不沿用客户端提供的 ID，防止会话固定
+		return newSession(o.IDLength)
+	}
+
+	var rec record
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return newSession(o.IDLength)
+	}
+	if rec.Values == nil {
+		rec.Values = make(map[string]any)
+	}
+	if rec.Flashes == nil {
+		rec.Flashes = make(map[string][]any)
+	}
+	return &Session{
+		id:        id,
+		values:    rec.Values,
+		flashes:   rec.Flashes,
+		createdAt: rec.CreatedAt,
+		idLength:  o.IDLength,
+	}, nil
+}
+
+// save 持久化会话并写出 Cookie，每次保存都会重置过期时间（滑动过期）
+func save(ctx context.Context, c *gin.Context, store Store, o *Options, s *Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.staleID != "" {
+		if err := store.Delete(ctx, o.KeyPrefix+s.staleID); err != nil {
+			return err
+		}
+	}
+
+	if s.destroyed {
+		if !s.isNew {
+			if err := store.Delete(ctx, o.KeyPrefix+s.id); err != nil {
+				return err
+			}
+		}
+		setCookie(c, o, "", -1)
+		return nil
+	}
+
+	if s.isNew && len(s.values) == 0 && len(s.flashes) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(s.record())
+	if err != nil {
+		return err
+	}
+	if err := store.Save(ctx, o.KeyPrefix+s.id, data, o.IdleTimeout); err != nil {
+		return err
+	}
+	setCookie(c, o, s.id, int(o.IdleTimeout.Seconds()))
+	return nil
+}
+
+// setCookie 写入会话 Cookie
+func setCookie(c *gin.Context, o *Options, value string, maxAge int) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     o.CookieName,
+		Value:    value,
+		Path:     o.Path,
+		Domain:   o.Domain,
+		MaxAge:   maxAge,
+		Secure:   o.Secure,
+		HttpOnly: o.HttpOnly,
+		SameSite: o.SameSite,
+	})
+}
+
+// sessionWriter 在响应头写出前触发会话保存，确保 Set-Cookie 能随响应下发
+type sessionWriter struct {
+	gin.ResponseWriter
+	once sync.Once
+	save func()
+}
+
+// commit 保存会话，仅执行一次
+func (w *sessionWriter) commit() {
+	w.once.Do(w.save)
+}
+
+// WriteHeaderNow 实现 gin.ResponseWriter
+func (w *sessionWriter) WriteHeaderNow() {
+	w.commit()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+// Write 实现 io.Writer
+func (w *sessionWriter) Write(data []byte) (int, error) {
+	w.commit()
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString 实现 io.StringWriter
+func (w *sessionWriter) WriteString(s string) (int, error) {
+	w.commit()
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Flush 实现 http.Flusher
+func (w *sessionWriter) Flush() {
+	w.commit()
+	w.ResponseWriter.Flush()
+}
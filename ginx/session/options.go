@@ -0,0 +1,160 @@
+package session
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/3086953492/gokit/ginx/cookie"
+)
+
+// 默认配置值
+const (
+	DefaultCookieName  = "session_id"
+	DefaultIdleTimeout = 30 * time.Minute
+)
+
+// Options 会话中间件配置，Cookie 相关字段与 ginx/cookie.Options 含义一致
+type Options struct {
+	// CookieName 会话 Cookie 名称，默认 "session_id"
+	CookieName string
+
+	// Domain Cookie 作用域，默认空（浏览器自动使用当前域名）
+	Domain string
+
+	// Path Cookie 有效路径，默认 "/"
+	Path string
+
+	// Secure 是否仅通过 HTTPS 传输，默认 false（生产环境建议开启）
+	Secure bool
+
+	// HttpOnly 禁止 JS 访问，默认 true
+	HttpOnly bool
+
+	// SameSite Cookie 同站策略，默认 Lax
+	SameSite http.SameSite
+
+	// IdleTimeout 会话空闲超时，每次请求都会重置（滑动过期），默认 30 分钟
+	IdleTimeout time.Duration
+
+	// KeyPrefix 会话在 Store 中的 key 前缀，默认 "session:"
+	KeyPrefix string
+
+	// IDLength 会话 ID 长度（URL-safe 字符），默认 43（约 256 位熵）
+	IDLength int
+
+	// ErrorHandler 会话保存失败时的回调，默认忽略。
+	// 保存发生在响应头写出前，此时无法再改变响应状态，仅适合记录日志。
+	ErrorHandler func(c *gin.Context, err error)
+}
+
+// defaultOptions 返回带有合理默认值的 Options
+func defaultOptions() *Options {
+	return &Options{
+		CookieName:  DefaultCookieName,
+		Path:        cookie.DefaultPath,
+		HttpOnly:    true,
+		SameSite:    http.SameSiteLaxMode,
+		IdleTimeout: DefaultIdleTimeout,
+		KeyPrefix:   "session:",
+		IDLength:    43,
+	}
+}
+
+// Option 配置函数类型
+type Option func(*Options)
+
+// WithCookieOptions 复用 ginx/cookie 的 Domain、Path、Secure、HttpOnly、SameSite 配置
+func WithCookieOptions(co *cookie.Options) Option {
+	return func(o *Options) {
+		if co == nil {
+			return
+		}
+		o.Domain = co.Domain
+		if co.Path != "" {
+			o.Path = co.Path
+		}
+		o.Secure = co.Secure
+		o.HttpOnly = co.HttpOnly
+		o.SameSite = co.SameSite
+	}
+}
+
+// WithCookieName 设置会话 Cookie 名称
+func WithCookieName(name string) Option {
+	return func(o *Options) {
+		if name != "" {
+			o.CookieName = name
+		}
+	}
+}
+
+// WithDomain 设置 Cookie 作用域
+func WithDomain(domain string) Option {
+	return func(o *Options) {
+		o.Domain = domain
+	}
+}
+
+// WithPath 设置 Cookie 有效路径
+func WithPath(path string) Option {
+	return func(o *Options) {
+		if path != "" {
+			o.Path = path
+		}
+	}
+}
+
+// WithSecure 设置是否仅通过 HTTPS 传输
+func WithSecure(secure bool) Option {
+	return func(o *Options) {
+		o.Secure = secure
+	}
+}
+
+// WithHttpOnly 设置是否禁止 JS 访问
+func WithHttpOnly(httpOnly bool) Option {
+	return func(o *Options) {
+		o.HttpOnly = httpOnly
+	}
+}
+
+// WithSameSite 设置 Cookie 同站策略
+func WithSameSite(sameSite http.SameSite) Option {
+	return func(o *Options) {
+		o.SameSite = sameSite
+	}
+}
+
+// WithIdleTimeout 设置会话空闲超时
+func WithIdleTimeout(d time.Duration) Option {
+	return func(o *Options) {
+		if d > 0 {
+			o.IdleTimeout = d
+		}
+	}
+}
+
+// WithKeyPrefix 设置会话在 Store 中的 key 前缀
+func WithKeyPrefix(prefix string) Option {
+	return func(o *Options) {
+		o.KeyPrefix = prefix
+	}
+}
+
+// WithIDLength 设置会话 ID 长度
+func WithIDLength(n int) Option {
+	return func(o *Options) {
+		if n > 0 {
+			o.IDLength = n
+		}
+	}
+}
+
+// WithErrorHandler 设置会话保存失败回调
+func WithErrorHandler(fn func(c *gin.Context, err error)) Option {
+	return func(o *Options) {
+		o.ErrorHandler = fn
+	}
+}
@@ -0,0 +1,159 @@
+package session
+
+import (
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/3086953492/gokit/security/random"
+)
+
+// Session 单个请求内的会话对象，由 Middleware 加载并在响应写出前自动保存。
+// 值以 JSON 编码保存，读回后数字类型为 float64，结构体为 map[string]any。
+type Session struct {
+	mu sync.Mutex
+
+	id        string
+	values    map[string]any
+	flashes   map[string][]any
+	createdAt time.Time
+	idLength  int
+
+	// staleID Regenerate 前的会话 ID，保存时从 Store 中删除
+	staleID   string
+	isNew     bool
+	destroyed bool
+}
+
+// newSession 创建空会话并生成新 ID
+func newSession(idLength int) (*Session, error) {
+	id, err := random.URLSafe(idLength)
+	if err != nil {
+		return nil, err
+	}
+	return &Session{
+		id:        id,
+		values:    make(map[string]any),
+		flashes:   make(map[string][]any),
+		createdAt: time.Now(),
+		idLength:  idLength,
+		isNew:     true,
+	}, nil
+}
+
+// ID 返回当前会话 ID
+func (s *Session) ID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.id
+}
+
+// IsNew 返回会话是否在本次请求中新建
+func (s *Session) IsNew() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.isNew
+}
+
+// CreatedAt 返回会话创建时间
+func (s *Session) CreatedAt() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.createdAt
+}
+
+// Get 获取会话值
+func (s *Session) Get(key string) (any, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.values[key]
+	return v, ok
+}
+
+// GetString 获取字符串类型的会话值，不存在或类型不符时返回空字符串
+func (s *Session) GetString(key string) string {
+	v, _ := s.Get(key)
+	str, _ := v.(string)
+	return str
+}
+
+// Set 设置会话值，value 需可被 JSON 编码
+func (s *Session) Set(key string, value any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[key] = value
+}
+
+// Delete 删除会话值
+func (s *Session) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.values, key)
+}
+
+// Values 返回全部会话值的副本
+func (s *Session) Values() map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return maps.Clone(s.values)
+}
+
+// Clear 清空全部会话值与闪存消息
+func (s *Session) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.values)
+	clear(s.flashes)
+}
+
+// AddFlash 添加一条闪存消息，消息在下一次 Flashes 读取后即被移除
+func (s *Session) AddFlash(key string, value any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.flashes[key] = append(s.flashes[key], value)
+}
+
+// Flashes 读取并移除指定 key 下的全部闪存消息
+func (s *Session) Flashes(key string) []any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	values := s.flashes[key]
+	delete(s.flashes, key)
+	return values
+}
+
+// Regenerate 为会话生成新 ID 并保留现有数据，旧 ID 在保存时失效。
+// 应在登录、提权等身份变化后调用，防止会话固定攻击。
+func (s *Session) Regenerate() error {
+	id, err := random.URLSafe(s.idLength)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.isNew && s.staleID == "" {
+		s.staleID = s.id
+	}
+	s.id = id
+	s.destroyed = false
+	return nil
+}
+
+// Destroy 销毁会话：从 Store 中删除并清除客户端 Cookie
+func (s *Session) Destroy() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.destroyed = true
+	clear(s.values)
+	clear(s.flashes)
+}
+
+// record 导出会话的持久化结构
+func (s *Session) record() *record {
+	return &record{
+		Values:    s.values,
+		Flashes:   s.flashes,
+		CreatedAt: s.createdAt,
+	}
+}
@@ -0,0 +1,96 @@
+package session
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/3086953492/gokit/redis"
+)
+
+var (
+	_ Store = (*RedisStore)(nil)
+	_ Store = (*MemoryStore)(nil)
+)
+
+// RedisStore 基于 redis.Manager 的会话存储
+type RedisStore struct {
+	mgr *redis.Manager
+}
+
+// NewRedisStore 创建 Redis 会话存储
+func NewRedisStore(mgr *redis.Manager) *RedisStore {
+	return &RedisStore{mgr: mgr}
+}
+
+// Load 实现 Store 接口
+func (s *RedisStore) Load(ctx context.Context, id string) ([]byte, error) {
+	return s.mgr.GetBytes(ctx, id)
+}
+
+// Save 实现 Store 接口
+func (s *RedisStore) Save(ctx context.Context, id string, data []byte, ttl time.Duration) error {
+	return s.mgr.SetBytes(ctx, id, data, ttl)
+}
+
+// Delete 实现 Store 接口
+func (s *RedisStore) Delete(ctx context.Context, id string) error {
+	_, err := s.mgr.Del(ctx, id)
+	return err
+}
+
+// MemoryStore 进程内会话存储，适用于单实例部署与测试
+// 使用惰性过期策略，写入时顺带清理过期会话
+type MemoryStore struct {
+	mu   sync.Mutex
+	data map[string]memoryEntry
+}
+
+type memoryEntry struct {
+	data     []byte
+	expireAt time.Time
+}
+
+// NewMemoryStore 创建内存会话存储
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{data: make(map[string]memoryEntry)}
+}
+
+// Load 实现 Store 接口
+func (s *MemoryStore) Load(ctx context.Context, id string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.data[id]
+	if !ok {
+		return nil, nil
+	}
+	if time.Now().After(e.expireAt) {
+		delete(s.data, id)
+		return nil, nil
+	}
+	return e.data, nil
+}
+
+// Save 实现 Store 接口
+func (s *MemoryStore) Save(ctx context.Context, id string, data []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for k, e := range s.data {
+		if now.After(e.expireAt) {
+			delete(s.data, k)
+		}
+	}
+	s.data[id] = memoryEntry{data: data, expireAt: now.Add(ttl)}
+	return nil
+}
+
+// Delete 实现 Store 接口
+func (s *MemoryStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	delete(s.data, id)
+	s.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,40 @@
+// Package session 提供基于服务端存储的会话管理与 gin 中间件。
+//
+// 会话 ID 由 security/random 生成并通过 Cookie 下发，会话数据保存在 Store 中
+// （内置 Redis 与内存两种实现）。每次请求都会重置会话过期时间（滑动过期），
+// 登录成功后应调用 Session.Regenerate 更换会话 ID，防止会话固定攻击。
+//
+//	store := session.NewRedisStore(redisMgr)
+//	r.Use(session.Middleware(store, session.WithSecure(true)))
+//
+//	r.POST("/login", func(c *gin.Context) {
+//		s := session.From(c)
+//		s.Regenerate()
+//		s.Set("user_id", user.ID)
+//		s.AddFlash("notice", "登录成功")
+//	})
+package session
+
+import (
+	"context"
+	"time"
+)
+
+// Store 会话数据存储接口，数据以编码后的字节形式保存
+type Store interface {
+	// Load 读取会话数据，会话不存在或已过期时返回 (nil, nil)
+	Load(ctx context.Context, id string) ([]byte, error)
+
+	// Save 保存会话数据并将过期时间重置为 ttl
+	Save(ctx context.Context, id string, data []byte, ttl time.Duration) error
+
+	// Delete 删除会话
+	Delete(ctx context.Context, id string) error
+}
+
+// record 会话数据的持久化结构
+type record struct {
+	Values    map[string]any   `json:"values,omitempty"`
+	Flashes   map[string][]any `json:"flashes,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+}
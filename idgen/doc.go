@@ -0,0 +1,21 @@
+// Package idgen 提供 Snowflake 风格的 64 位分布式唯一 ID 生成。
+//
+// ID 布局（最高位恒为 0，保证 ID 为正数）：
+//
+//	| 1 bit 符号位 | TimeBits 时间戳 | NodeBits 节点 ID | SequenceBits 序列号 |
+//
+// 默认 41 位毫秒时间戳、10 位节点 ID、12 位序列号，可通过选项调整，三者之和必须为 63。
+//
+// 节点 ID 可以静态配置（如 config.ServerConfig.ID），
+// 也可以通过 LeaseNode 从 Redis 租用，避免多副本手工分配冲突：
+//
+//	lease, err := idgen.LeaseNode(ctx, redisMgr)
+//	if err != nil {
+//		return err
+//	}
+//	defer lease.Close(context.Background())
+//
+//	gen, err := idgen.NewGenerator(idgen.WithNodeLease(lease))
+//	id, err := gen.Next()
+//	parts := gen.Decompose(id)
+package idgen
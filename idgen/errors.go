@@ -0,0 +1,26 @@
+package idgen
+
+import "errors"
+
+var (
+	// ErrInvalidLayout 表示位布局无效（三段位数之和必须为 63）
+	ErrInvalidLayout = errors.New("idgen: invalid bit layout")
+
+	// ErrInvalidNodeID 表示节点 ID 超出布局允许的范围
+	ErrInvalidNodeID = errors.New("idgen: invalid node id")
+
+	// ErrInvalidEpoch 表示起始时间晚于当前时间
+	ErrInvalidEpoch = errors.New("idgen: invalid epoch")
+
+	// ErrClockBackwards 表示系统时钟回拨超过容忍范围
+	ErrClockBackwards = errors.New("idgen: clock moved backwards")
+
+	// ErrTimeOverflow 表示时间戳已超出 TimeBits 可表示的范围
+	ErrTimeOverflow = errors.New("idgen: timestamp overflow")
+
+	// ErrNoNodeAvailable 表示所有节点 ID 均已被租用
+	ErrNoNodeAvailable = errors.New("idgen: no node id available")
+
+	// ErrNodeLeaseLost 表示节点 ID 租约已丢失，继续生成可能产生重复 ID
+	ErrNodeLeaseLost = errors.New("idgen: node lease lost")
+)
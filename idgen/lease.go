@@ -0,0 +1,203 @@
+package idgen
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/3086953492/gokit/redis"
+)
+
+// LeaseOption 配置节点 ID 租约
+type LeaseOption func(*leaseConfig)
+
+type leaseConfig struct {
+	keyPrefix string
+	ttl       time.Duration
+	maxNodeID int64
+	onLost    func(id int64)
+}
+
+// WithLeaseKeyPrefix 设置租约 key 前缀，默认 "idgen:node:"
+func WithLeaseKeyPrefix(prefix string) LeaseOption {
+	return func(c *leaseConfig) {
+		c.keyPrefix = prefix
+	}
+}
+
+// minLeaseTTL 租约时长下限，保证续期间隔与安全余量有意义
+const minLeaseTTL = time.Second
+
+// WithLeaseTTL 设置租约时长，默认 30 秒，租约每隔 ttl/3 自动续期
+// 进程崩溃后其节点 ID 最迟在 ttl 之后可被其他进程租用
+// 非正值被忽略，小于 1 秒时按 1 秒处理
+func WithLeaseTTL(ttl time.Duration) LeaseOption {
+	return func(c *leaseConfig) {
+		if ttl > 0 {
+			c.ttl = ttl
+		}
+	}
+}
+
+// WithLeaseMaxNodeID 设置可租用的最大节点 ID，应与 Generator 的 NodeBits 匹配
+// 默认 1023（对应默认 10 位节点 ID）
+func WithLeaseMaxNodeID(max int64) LeaseOption {
+	return func(c *leaseConfig) {
+		c.maxNodeID = max
+	}
+}
+
+// WithLeaseLost 设置租约丢失回调
+func WithLeaseLost(fn func(id int64)) LeaseOption {
+	return func(c *leaseConfig) {
+		c.onLost = fn
+	}
+}
+
+// NodeLease 从 Redis 租用的节点 ID，后台自动续期
+type NodeLease struct {
+	id   int64
+	lock *redis.DistributedLock
+	cfg  leaseConfig
+
+	// deadline 本地认定的租约到期时间（UnixNano），取发起请求前的时刻加 ttl 再减去安全余量
+	deadline atomic.Int64
+
+	cancel    context.CancelFunc
+	done      chan struct{}
+	lost      chan struct{}
+	lostOnce  sync.Once
+	closeOnce sync.Once
+}
+
+// LeaseNode 从 Redis 租用一个未被占用的节点 ID。
+// 从随机位置开始依次尝试 SETNX，所有 ID 均被占用时返回 ErrNoNodeAvailable。
+func LeaseNode(ctx context.Context, mgr *redis.Manager, opts ...LeaseOption) (*NodeLease, error) {
+	cfg := leaseConfig{
+		keyPrefix: "idgen:node:",
+		ttl:       30 * time.Second,
+		maxNodeID: int64(1)<<DefaultNodeBits - 1,
+	}
+	for _, o := range opts {
+		o(&cfg)
+	}
+	cfg.ttl = max(cfg.ttl, minLeaseTTL)
+
+	total := cfg.maxNodeID + 1
+	offset := rand.Int64N(total)
+	for i := int64(0); i < total; i++ {
+		id := (offset + i) % total
+		lock := mgr.NewDistributedLock(cfg.keyPrefix+strconv.FormatInt(id, 10), cfg.ttl)
+		start := time.Now()
+		err := lock.Acquire(ctx)
+		if errors.Is(err, redis.ErrLockAcquireFailed) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		renewCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		l := &NodeLease{
+			id:     id,
+			lock:   lock,
+			cfg:    cfg,
+			cancel: cancel,
+			done:   make(chan struct{}),
+			lost:   make(chan struct{}),
+		}
+		l.extend(start)
+		go l.keepAlive(renewCtx)
+		return l, nil
+	}
+	return nil, ErrNoNodeAvailable
+}
+
+// ID 返回租用的节点 ID
+func (l *NodeLease) ID() int64 {
+	return l.id
+}
+
+// Lost 返回租约是否已丢失。
+// 本地租约到期（续期未能及时成功）后立即返回 true，此时 Redis 中的 key 可能已过期并被其他进程租用。
+func (l *NodeLease) Lost() bool {
+	select {
+	case <-l.lost:
+		return true
+	default:
+		return time.Now().UnixNano() >= l.deadline.Load()
+	}
+}
+
+// LostC 返回租约丢失时关闭的 channel
+func (l *NodeLease) LostC() <-chan struct{} {
+	return l.lost
+}
+
+// Close 停止续期并释放节点 ID
+func (l *NodeLease) Close(ctx context.Context) error {
+	var err error
+	l.closeOnce.Do(func() {
+		l.cancel()
+		<-l.done
+		err = l.lock.Release(ctx)
+	})
+	return err
+}
+
+// keepAlive 定期续期租约，续期失败且租约到期后标记为丢失
+func (l *NodeLease) keepAlive(ctx context.Context) {
+	defer close(l.done)
+
+	interval := l.cfg.ttl / 3
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		// 本地租约到期时立即标记丢失，不等待下一次续期
+		expiry := time.NewTimer(time.Until(time.Unix(0, l.deadline.Load())))
+		select {
+		case <-ctx.Done():
+			expiry.Stop()
+			return
+		case <-expiry.C:
+			l.markLost()
+			return
+		case <-ticker.C:
+			expiry.Stop()
+		}
+
+		start := time.Now()
+		renewCtx, cancel := context.WithTimeout(ctx, interval)
+		err := l.lock.Refresh(renewCtx)
+		cancel()
+
+		switch {
+		case err == nil:
+			l.extend(start)
+		case errors.Is(err, redis.ErrLockNotHeld), l.Lost():
+			l.markLost()
+			return
+		}
+	}
+}
+
+// extend 以发起请求前的时刻 start 为起点更新本地租约到期时间，
+// 并扣除 ttl 的十分之一作为时钟漂移与网络延迟的安全余量，保证本地租约先于 Redis 中的 key 到期
+func (l *NodeLease) extend(start time.Time) {
+	l.deadline.Store(start.Add(l.cfg.ttl - l.cfg.ttl/10).UnixNano())
+}
+
+// markLost 标记租约丢失并触发回调
+func (l *NodeLease) markLost() {
+	l.lostOnce.Do(func() {
+		close(l.lost)
+		if l.cfg.onLost != nil {
+			l.cfg.onLost(l.id)
+		}
+	})
+}
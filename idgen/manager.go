@@ -0,0 +1,140 @@
+package idgen
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Generator Snowflake 风格的 ID 生成器，线程安全
+type Generator struct {
+	opts *Options
+
+	nodeShift uint8
+	timeShift uint8
+	maxSeq    int64
+	maxNode   int64
+	maxTime   int64
+	epochNano int64
+
+	mu       sync.Mutex
+	lastTime int64
+	sequence int64
+}
+
+// NewGenerator 创建 ID 生成器
+func NewGenerator(opts ...Option) (*Generator, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if int(o.TimeBits)+int(o.NodeBits)+int(o.SequenceBits) != 63 || o.TimeBits == 0 || o.SequenceBits == 0 {
+		return nil, fmt.Errorf("%w: %d+%d+%d", ErrInvalidLayout, o.TimeBits, o.NodeBits, o.SequenceBits)
+	}
+	if o.TimeUnit <= 0 {
+		return nil, fmt.Errorf("%w: time unit must be positive", ErrInvalidLayout)
+	}
+
+	maxNode := int64(1)<<o.NodeBits - 1
+	if o.NodeID < 0 || o.NodeID > maxNode {
+		return nil, fmt.Errorf("%w: %d not in [0, %d]", ErrInvalidNodeID, o.NodeID, maxNode)
+	}
+	if o.Epoch.After(time.Now()) {
+		return nil, ErrInvalidEpoch
+	}
+
+	return &Generator{
+		opts:      o,
+		nodeShift: o.SequenceBits,
+		timeShift: o.SequenceBits + o.NodeBits,
+		maxSeq:    int64(1)<<o.SequenceBits - 1,
+		maxNode:   maxNode,
+		maxTime:   int64(1)<<o.TimeBits - 1,
+		epochNano: o.Epoch.UnixNano(),
+		lastTime:  -1,
+	}, nil
+}
+
+// NodeID 返回当前节点 ID
+func (g *Generator) NodeID() int64 {
+	return g.opts.NodeID
+}
+
+// Next 生成下一个 ID。
+// 使用节点 ID 租约时，租约丢失或本地租约到期后返回 ErrNodeLeaseLost；
+// 同一时间单位内序列号用尽时等待下一个时间单位；
+// 时钟回拨不超过 MaxClockBackward 时等待时钟追上，否则返回 ErrClockBackwards。
+func (g *Generator) Next() (int64, error) {
+	if g.opts.Lease != nil && g.opts.Lease.Lost() {
+		return 0, ErrNodeLeaseLost
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.tick()
+	if now < g.lastTime {
+		backward := time.Duration(g.lastTime-now) * g.opts.TimeUnit
+		if backward > g.opts.MaxClockBackward {
+			return 0, fmt.Errorf("%w: %s", ErrClockBackwards, backward)
+		}
+		time.Sleep(backward)
+		now = g.waitAfter(g.lastTime - 1)
+	}
+
+	if now == g.lastTime {
+		g.sequence = (g.sequence + 1) & g.maxSeq
+		if g.sequence == 0 {
+			now = g.waitAfter(g.lastTime)
+		}
+	} else {
+		g.sequence = 0
+	}
+
+	if now > g.maxTime {
+		return 0, ErrTimeOverflow
+	}
+	// 等待时钟期间租约可能到期，在确定时间戳之后检查
+	if g.opts.Lease != nil && g.opts.Lease.Lost() {
+		return 0, ErrNodeLeaseLost
+	}
+	g.lastTime = now
+
+	return now<<g.timeShift | g.opts.NodeID<<g.nodeShift | g.sequence, nil
+}
+
+// MustNext 生成下一个 ID，出错时 panic
+func (g *Generator) MustNext() int64 {
+	id, err := g.Next()
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// Decompose 将 ID 分解为时间、节点与序列号
+// 需使用与生成时相同的 Epoch、TimeUnit 与位布局
+func (g *Generator) Decompose(id int64) Parts {
+	ticks := id >> g.timeShift
+	return Parts{
+		Time:     time.Unix(0, g.epochNano+ticks*int64(g.opts.TimeUnit)),
+		Node:     (id >> g.nodeShift) & g.maxNode,
+		Sequence: id & g.maxSeq,
+	}
+}
+
+// tick 返回自 Epoch 起经过的时间单位数
+func (g *Generator) tick() int64 {
+	return (time.Now().UnixNano() - g.epochNano) / int64(g.opts.TimeUnit)
+}
+
+// waitAfter 自旋等待直到时间单位大于 last
+func (g *Generator) waitAfter(last int64) int64 {
+	now := g.tick()
+	for now <= last {
+		time.Sleep(g.opts.TimeUnit / 10)
+		now = g.tick()
+	}
+	return now
+}
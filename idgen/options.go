@@ -0,0 +1,94 @@
+package idgen
+
+import "time"
+
+// Options 定义 Generator 的配置选项
+type Options struct {
+	// Epoch 时间戳起点，默认 DefaultEpoch
+	Epoch time.Time
+
+	// TimeUnit 时间戳精度，默认 1 毫秒
+	TimeUnit time.Duration
+
+	// TimeBits 时间戳位数，默认 41
+	TimeBits uint8
+
+	// NodeBits 节点 ID 位数，默认 10（最多 1024 个节点）
+	NodeBits uint8
+
+	// SequenceBits 序列号位数，默认 12（每个时间单位 4096 个 ID）
+	SequenceBits uint8
+
+	// NodeID 静态节点 ID，使用 WithNodeLease 时被租约中的 ID 覆盖
+	NodeID int64
+
+	// MaxClockBackward 可容忍的时钟回拨时长，回拨在此范围内时等待时钟追上，
+	// 超出时返回 ErrClockBackwards，默认 10 毫秒
+	MaxClockBackward time.Duration
+
+	// Lease 节点 ID 租约，可选
+	Lease *NodeLease
+}
+
+// Option 是配置选项函数类型
+type Option func(*Options)
+
+// defaultOptions 返回默认配置
+func defaultOptions() *Options {
+	return &Options{
+		Epoch:            DefaultEpoch,
+		TimeUnit:         time.Millisecond,
+		TimeBits:         DefaultTimeBits,
+		NodeBits:         DefaultNodeBits,
+		SequenceBits:     DefaultSequenceBits,
+		MaxClockBackward: 10 * time.Millisecond,
+	}
+}
+
+// WithEpoch 设置时间戳起点
+func WithEpoch(epoch time.Time) Option {
+	return func(o *Options) {
+		o.Epoch = epoch
+	}
+}
+
+// WithTimeUnit 设置时间戳精度，如 10 毫秒可延长可用年限
+func WithTimeUnit(unit time.Duration) Option {
+	return func(o *Options) {
+		o.TimeUnit = unit
+	}
+}
+
+// WithLayout 设置位布局，三者之和必须为 63
+func WithLayout(timeBits, nodeBits, sequenceBits uint8) Option {
+	return func(o *Options) {
+		o.TimeBits = timeBits
+		o.NodeBits = nodeBits
+		o.SequenceBits = sequenceBits
+	}
+}
+
+// WithNodeID 设置静态节点 ID，如 config.ServerConfig.ID
+func WithNodeID(id int64) Option {
+	return func(o *Options) {
+		o.NodeID = id
+	}
+}
+
+// WithMaxClockBackward 设置可容忍的时钟回拨时长
+func WithMaxClockBackward(d time.Duration) Option {
+	return func(o *Options) {
+		o.MaxClockBackward = d
+	}
+}
+
+// WithNodeLease 使用从 Redis 租用的节点 ID
+// 租约丢失后 Generator.Next 返回 ErrNodeLeaseLost
+func WithNodeLease(lease *NodeLease) Option {
+	return func(o *Options) {
+		o.Lease = lease
+		if lease != nil {
+			o.NodeID = lease.ID()
+		}
+	}
+}
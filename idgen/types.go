@@ -0,0 +1,26 @@
+package idgen
+
+import "time"
+
+// 默认位布局
+const (
+	DefaultTimeBits     = 41
+	DefaultNodeBits     = 10
+	DefaultSequenceBits = 12
+)
+
+// DefaultEpoch 默认起始时间：2024-01-01 00:00:00 UTC
+// 41 位毫秒时间戳可使用约 69 年
+var DefaultEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Parts 是 ID 分解后的各组成部分
+type Parts struct {
+	// Time ID 生成时间（精度为 TimeUnit）
+	Time time.Time
+
+	// Node 节点 ID
+	Node int64
+
+	// Sequence 同一时间单位内的序列号
+	Sequence int64
+}
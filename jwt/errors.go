@@ -13,12 +13,51 @@ var (
 	// ErrRefreshSecretNotConfigured 表示未配置刷新令牌密钥。
 	ErrRefreshSecretNotConfigured = errors.New("jwt: refresh secret not configured")
 
+	// ErrInvalidKey 表示非对称密钥无法解析或类型不受支持。
+	ErrInvalidKey = errors.New("jwt: invalid key")
+
+	// ErrAlgorithmNotAllowed 表示密钥算法不在 AllowedAlgorithms 白名单中。
+	ErrAlgorithmNotAllowed = errors.New("jwt: algorithm not allowed")
+
+	// ErrSigningKeyNotConfigured 表示仅配置了验签公钥，无法签发令牌。
+	ErrSigningKeyNotConfigured = errors.New("jwt: signing key not configured")
+
+	// ErrKeyNotFound 表示密钥集中不存在指定 kid 的密钥。
+	ErrKeyNotFound = errors.New("jwt: key not found")
+
+	// ErrKeyInUse 表示试图移除或替换当前签名密钥。
+	ErrKeyInUse = errors.New("jwt: key is the active signing key")
+
+	// ErrDuplicateKeyID 表示密钥集中已存在相同 kid 的密钥。
+	ErrDuplicateKeyID = errors.New("jwt: duplicate key id")
+
+	// ErrJWKSFetch 表示拉取或解析远程 JWKS 失败。
+	ErrJWKSFetch = errors.New("jwt: fetch jwks failed")
+
 	// ErrInvalidToken 表示令牌格式不正确或签名验证失败。
 	ErrInvalidToken = errors.New("jwt: invalid token")
 
 	// ErrTokenExpired 表示令牌已过期。
 	ErrTokenExpired = errors.New("jwt: token expired")
 
+	// ErrTokenRevoked 表示令牌已被撤销。
+	ErrTokenRevoked = errors.New("jwt: token revoked")
+
+	// ErrRefreshTokenReused 表示已使用过的刷新令牌被再次使用，所属家族已被整体撤销。
+	ErrRefreshTokenReused = errors.New("jwt: refresh token reused")
+
+	// ErrRotationEnabled 表示已启用刷新令牌轮换，需改用 RefreshTokenPair。
+	ErrRotationEnabled = errors.New("jwt: refresh token rotation enabled, use RefreshTokenPair")
+
+	// ErrRevokerNotConfigured 表示未配置 Revoker，无法执行撤销操作。
+	ErrRevokerNotConfigured = errors.New("jwt: revoker not configured")
+
+	// ErrSubjectManagerNotConfigured 表示未配置 subject.Manager，无法签发 ID 令牌。
+	ErrSubjectManagerNotConfigured = errors.New("jwt: subject manager not configured")
+
+	// ErrClientIDRequired 表示签发或验证 ID 令牌时未提供 client_id。
+	ErrClientIDRequired = errors.New("jwt: client id is required")
+
 	// ErrInvalidTokenType 表示令牌类型与预期不符（如期望 refresh 却传入 access）。
 	ErrInvalidTokenType = errors.New("jwt: invalid token type")
 
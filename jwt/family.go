@@ -0,0 +1,165 @@
+package jwt
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/3086953492/gokit/redis"
+)
+
+// FamilyStore 定义刷新令牌家族的状态存储接口。
+// 同一家族在任意时刻只有一个有效的刷新令牌（以 jti 标识），
+// 每次刷新都会用新令牌替换旧令牌；旧令牌再次出现即视为被盗用，整个家族随之撤销。
+type FamilyStore interface {
+	// Create 创建家族并记录当前有效的刷新令牌 jti，记录保留 ttl。
+	Create(ctx context.Context, family, jti string, ttl time.Duration) error
+
+	// Rotate 原子地将家族当前令牌由 oldJTI 替换为 newJTI，并将记录有效期重置为 ttl。
+	// oldJTI 不是当前令牌时撤销整个家族并返回 ErrRefreshTokenReused；
+	// 家族不存在（已撤销或已过期）时返回 ErrTokenRevoked。
+	Rotate(ctx context.Context, family, oldJTI, newJTI string, ttl time.Duration) error
+
+	// Revoke 撤销整个家族，之后该家族的任何刷新令牌都无法使用。
+	Revoke(ctx context.Context, family string) error
+
+	// Current 返回家族当前有效的刷新令牌 jti，家族不存在（已撤销或已过期）时返回空字符串。
+	Current(ctx context.Context, family string) (string, error)
+}
+
+var (
+	_ FamilyStore = (*RedisFamilyStore)(nil)
+	_ FamilyStore = (*MemoryFamilyStore)(nil)
+)
+
+// familyRotateScript 比较并替换家族当前令牌
+// 返回 1 替换成功，0 检测到重用（已删除家族），-1 家族不存在
+// KEYS[1] 家族 key，ARGV[1] 旧 jti，ARGV[2] 新 jti，ARGV[3] 有效期毫秒数
+var familyRotateScript = redis.NewScript(`
+	local current = redis.call("get", KEYS[1])
+	if not current then
+		return -1
+	end
+	if current ~= ARGV[1] then
+		redis.call("del", KEYS[1])
+		return 0
+	end
+	redis.call("set", KEYS[1], ARGV[2], "PX", ARGV[3])
+	return 1
+`)
+
+// RedisFamilyStore 基于 redis.Manager 的刷新令牌家族存储，key 形如 "jwt:family:<family>"。
+type RedisFamilyStore struct {
+	mgr    *redis.Manager
+	prefix string
+}
+
+// NewRedisFamilyStore 创建 Redis 刷新令牌家族存储。
+func NewRedisFamilyStore(mgr *redis.Manager) *RedisFamilyStore {
+	return &RedisFamilyStore{mgr: mgr, prefix: "jwt:family:"}
+}
+
+// Create 实现 FamilyStore 接口。
+func (s *RedisFamilyStore) Create(ctx context.Context, family, jti string, ttl time.Duration) error {
+	return s.mgr.SetBytes(ctx, s.prefix+family, []byte(jti), ttl)
+}
+
+// Rotate 实现 FamilyStore 接口，使用 Lua 脚本保证比较与替换的原子性。
+func (s *RedisFamilyStore) Rotate(ctx context.Context, family, oldJTI, newJTI string, ttl time.Duration) error {
+	result, err := s.mgr.EvalScript(ctx, familyRotateScript, []string{s.prefix + family}, oldJTI, newJTI, ttl.Milliseconds())
+	if err != nil {
+		return err
+	}
+	switch n, _ := result.(int64); n {
+	case 1:
+		return nil
+	case 0:
+		return ErrRefreshTokenReused
+	default:
+		return ErrTokenRevoked
+	}
+}
+
+// Revoke 实现 FamilyStore 接口。
+func (s *RedisFamilyStore) Revoke(ctx context.Context, family string) error {
+	_, err := s.mgr.Del(ctx, s.prefix+family)
+	return err
+}
+
+// Current 实现 FamilyStore 接口。
+func (s *RedisFamilyStore) Current(ctx context.Context, family string) (string, error) {
+	data, err := s.mgr.GetBytes(ctx, s.prefix+family)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// MemoryFamilyStore 进程内刷新令牌家族存储，适用于单实例部署与测试。
+type MemoryFamilyStore struct {
+	mu       sync.Mutex
+	families map[string]familyEntry
+}
+
+type familyEntry struct {
+	jti      string
+	expireAt time.Time
+}
+
+// NewMemoryFamilyStore 创建内存刷新令牌家族存储。
+func NewMemoryFamilyStore() *MemoryFamilyStore {
+	return &MemoryFamilyStore{families: make(map[string]familyEntry)}
+}
+
+// Create 实现 FamilyStore 接口。
+func (s *MemoryFamilyStore) Create(ctx context.Context, family, jti string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for f, e := range s.families {
+		if now.After(e.expireAt) {
+			delete(s.families, f)
+		}
+	}
+	s.families[family] = familyEntry{jti: jti, expireAt: now.Add(ttl)}
+	return nil
+}
+
+// Rotate 实现 FamilyStore 接口。
+func (s *MemoryFamilyStore) Rotate(ctx context.Context, family, oldJTI, newJTI string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.families[family]
+	if !ok || time.Now().After(e.expireAt) {
+		delete(s.families, family)
+		return ErrTokenRevoked
+	}
+	if e.jti != oldJTI {
+		delete(s.families, family)
+		return ErrRefreshTokenReused
+	}
+	s.families[family] = familyEntry{jti: newJTI, expireAt: time.Now().Add(ttl)}
+	return nil
+}
+
+// Revoke 实现 FamilyStore 接口。
+func (s *MemoryFamilyStore) Revoke(ctx context.Context, family string) error {
+	s.mu.Lock()
+	delete(s.families, family)
+	s.mu.Unlock()
+	return nil
+}
+
+// Current 实现 FamilyStore 接口。
+func (s *MemoryFamilyStore) Current(ctx context.Context, family string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.families[family]
+	if !ok || time.Now().After(e.expireAt) {
+		return "", nil
+	}
+	return e.jti, nil
+}
@@ -0,0 +1,49 @@
+package jwt
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Grant 描述 OAuth 2.0 授权信息，写入令牌的 client_id 与 scope 声明（RFC 9068）。
+type Grant struct {
+	// ClientID 获得授权的客户端 ID。
+	ClientID string
+
+	// Scope 以空格分隔的授权范围。
+	Scope string
+}
+
+// Scopes 返回拆分后的授权范围。
+func (g Grant) Scopes() []string {
+	return strings.Fields(g.Scope)
+}
+
+// apply 将授权信息写入声明
+func (g Grant) apply(claims *Claims) {
+	claims.ClientID = g.ClientID
+	claims.Scope = g.Scope
+}
+
+// GenerateAccessTokenForGrant 生成携带 client_id 与 scope 的访问令牌。
+func (m *Manager) GenerateAccessTokenForGrant(subject string, extra map[string]any, grant Grant) (string, error) {
+	return m.generateAccess(subject, extra, grant)
+}
+
+// GenerateTokenPairForGrant 生成携带 client_id 与 scope 的令牌对。
+// 使用 RefreshTokenPair 刷新时，新令牌沿用刷新令牌中的授权信息。
+func (m *Manager) GenerateTokenPairForGrant(ctx context.Context, subject string, extra map[string]any, grant Grant) (string, string, error) {
+	return m.generatePair(ctx, subject, extra, grant)
+}
+
+// RefreshTokenPairWithScope 与 RefreshTokenPair 相同，scope 不为空时新访问令牌使用该 scope，
+// 新刷新令牌保留原有 scope（RFC 6749 §6）。调用方负责确认 scope 不超出原有授权范围。
+func (m *Manager) RefreshTokenPairWithScope(ctx context.Context, refreshToken, scope string) (string, string, error) {
+	return m.refreshPair(ctx, refreshToken, scope)
+}
+
+// AccessTTL 返回访问令牌有效期，用于填写令牌响应的 expires_in。
+func (m *Manager) AccessTTL() time.Duration {
+	return m.opts.AccessTTL
+}
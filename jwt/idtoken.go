@@ -0,0 +1,248 @@
+package jwt
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// idTokenClaimNames ID 令牌中由 IDTokenClaims 字段承载的声明，Extra 中的同名项会被忽略
+var idTokenClaimNames = map[string]bool{
+	"iss": true, "sub": true, "aud": true, "exp": true, "nbf": true, "iat": true, "jti": true,
+	"nonce": true, "auth_time": true, "acr": true, "amr": true, "at_hash": true, "c_hash": true, "azp": true,
+}
+
+// IDTokenClaims 定义 OpenID Connect Core 1.0 §2 的 ID 令牌声明。
+// Extra 中的声明（如 email、name）与标准声明位于同一层级。
+type IDTokenClaims struct {
+	// Nonce 认证请求中的 nonce，原样返回以防重放。
+	Nonce string `json:"nonce,omitempty"`
+
+	// AuthTime 终端用户完成认证的时间。
+	AuthTime *jwt.NumericDate `json:"auth_time,omitempty"`
+
+	// ACR 认证上下文类引用。
+	ACR string `json:"acr,omitempty"`
+
+	// AMR 认证方式引用，如 "pwd"、"otp"。
+	AMR []string `json:"amr,omitempty"`
+
+	// AtHash 访问令牌哈希。
+	AtHash string `json:"at_hash,omitempty"`
+
+	// CHash 授权码哈希。
+	CHash string `json:"c_hash,omitempty"`
+
+	// AuthorizedParty 被授权方（azp），aud 包含多个值时必须为客户端 ID。
+	AuthorizedParty string `json:"azp,omitempty"`
+
+	// Extra 其他顶层声明，如 email、name。
+	Extra map[string]any `json:"-"`
+
+	jwt.RegisteredClaims
+
+	// alg 验证时使用的签名算法，用于校验 at_hash 与 c_hash
+	alg string
+}
+
+// idTokenClaimsJSON 用于编解码 IDTokenClaims 的标准字段
+type idTokenClaimsJSON IDTokenClaims
+
+// MarshalJSON 将 Extra 展开到顶层，标准声明优先。
+func (c IDTokenClaims) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(idTokenClaimsJSON(c))
+	if err != nil || len(c.Extra) == 0 {
+		return data, err
+	}
+
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for name, v := range c.Extra {
+		if idTokenClaimNames[name] {
+			continue
+		}
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("jwt: encode claim %q: %w", name, err)
+		}
+		fields[name] = raw
+	}
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON 解码标准声明，其余顶层声明放入 Extra。
+func (c *IDTokenClaims) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, (*idTokenClaimsJSON)(c)); err != nil {
+		return err
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for name := range idTokenClaimNames {
+		delete(fields, name)
+	}
+	if len(fields) > 0 {
+		c.Extra = fields
+	}
+	return nil
+}
+
+// VerifyAccessToken 校验访问令牌与 at_hash 是否匹配（OIDC Core §3.2.2.9）。
+// 令牌不含 at_hash 时返回 nil：授权码流程中 at_hash 是可选的。
+// 仅适用于 IDTokenVerifier 验证得到的 Claims。
+func (c *IDTokenClaims) VerifyAccessToken(accessToken string) error {
+	return c.verifyHash(c.AtHash, accessToken, "at_hash")
+}
+
+// VerifyCode 校验授权码与 c_hash 是否匹配（OIDC Core §3.3.2.11）。
+// 令牌不含 c_hash 时返回 nil。仅适用于 IDTokenVerifier 验证得到的 Claims。
+func (c *IDTokenClaims) VerifyCode(code string) error {
+	return c.verifyHash(c.CHash, code, "c_hash")
+}
+
+// verifyHash 按验证时的签名算法计算哈希并比较
+func (c *IDTokenClaims) verifyHash(expected, value, name string) error {
+	if expected == "" {
+		return nil
+	}
+	if c.alg == "" {
+		return fmt.Errorf("%w: %s cannot be verified on unverified claims", ErrInvalidToken, name)
+	}
+	actual, err := tokenHash(c.alg, value)
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(actual), []byte(expected)) != 1 {
+		return fmt.Errorf("%w: %s mismatch", ErrInvalidToken, name)
+	}
+	return nil
+}
+
+// IDTokenParams 签发 ID 令牌的参数。
+type IDTokenParams struct {
+	// UserID 用户 ID，经 subject.Manager 转换为 sub，必填。
+	UserID string
+
+	// ClientID 客户端 ID，写入 aud，必填。
+	ClientID string
+
+	// Sector pairwise 模式的 sector 标识（通常为客户端 redirect_uri 的主机名），为空时生成 public sub。
+	Sector string
+
+	// Audience 除 ClientID 外的其他受众，不为空时 azp 写入 ClientID。
+	Audience []string
+
+	// Nonce 认证请求中的 nonce。
+	Nonce string
+
+	// AuthTime 用户完成认证的时间，为零值时不写入。
+	AuthTime time.Time
+
+	// ACR 认证上下文类引用。
+	ACR string
+
+	// AMR 认证方式引用。
+	AMR []string
+
+	// AccessToken 同时签发的访问令牌，不为空时写入 at_hash。
+	AccessToken string
+
+	// Code 同时签发的授权码，不为空时写入 c_hash。
+	Code string
+
+	// Extra 其他顶层声明，如 email、name。
+	Extra map[string]any
+}
+
+// GenerateIDToken 按 OpenID Connect Core 签发 ID 令牌，使用访问令牌的签名密钥。
+// 依赖方通常通过 JWKS 验证 ID 令牌，因此建议为访问令牌配置非对称密钥。
+//
+//	idToken, err := jwtMgr.GenerateIDToken(jwt.IDTokenParams{
+//		UserID:      userID,
+//		ClientID:    client.ID,
+//		Sector:      client.Sector, // pairwise 客户端
+//		Nonce:       req.Nonce,
+//		AuthTime:    session.AuthTime,
+//		AMR:         []string{"pwd"},
+//		AccessToken: accessToken,
+//	})
+func (m *Manager) GenerateIDToken(p IDTokenParams) (string, error) {
+	if m.access == nil {
+		return "", ErrAccessSecretNotConfigured
+	}
+	key := m.access.Active()
+	if key == nil {
+		return "", ErrSigningKeyNotConfigured
+	}
+	if m.opts.SubjectManager == nil {
+		return "", ErrSubjectManagerNotConfigured
+	}
+	if p.ClientID == "" {
+		return "", ErrClientIDRequired
+	}
+
+	sub, err := m.opts.SubjectManager.SubWithSector(p.UserID, p.Sector)
+	if err != nil {
+		return "", fmt.Errorf("jwt: generate subject: %w", err)
+	}
+
+	now := time.Now()
+	claims := &IDTokenClaims{
+		Nonce: p.Nonce,
+		ACR:   p.ACR,
+		AMR:   p.AMR,
+		Extra: p.Extra,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    m.opts.Issuer,
+			Subject:   sub,
+			Audience:  append(jwt.ClaimStrings{p.ClientID}, p.Audience...),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(m.opts.IDTokenTTL)),
+		},
+	}
+	if !p.AuthTime.IsZero() {
+		claims.AuthTime = jwt.NewNumericDate(p.AuthTime)
+	}
+	if len(p.Audience) > 0 {
+		claims.AuthorizedParty = p.ClientID
+	}
+	if p.AccessToken != "" {
+		if claims.AtHash, err = tokenHash(key.Algorithm(), p.AccessToken); err != nil {
+			return "", err
+		}
+	}
+	if p.Code != "" {
+		if claims.CHash, err = tokenHash(key.Algorithm(), p.Code); err != nil {
+			return "", err
+		}
+	}
+	return signToken(key, claims)
+}
+
+// tokenHash 计算 at_hash / c_hash：使用与签名算法对应的哈希函数，取摘要左半部分做 Base64URL 编码
+func tokenHash(alg, value string) (string, error) {
+	var h hash.Hash
+	switch alg {
+	case AlgHS256, AlgRS256, AlgES256, "PS256":
+		h = sha256.New()
+	case "HS384", "RS384", AlgES384, "PS384":
+		h = sha512.New384()
+	case "HS512", "RS512", AlgES512, "PS512", AlgEdDSA:
+		h = sha512.New()
+	default:
+		return "", fmt.Errorf("%w: unsupported algorithm %q for token hash", ErrInvalidKey, alg)
+	}
+	h.Write([]byte(value))
+	sum := h.Sum(nil)
+	return b64(sum[:len(sum)/2]), nil
+}
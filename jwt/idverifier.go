@@ -0,0 +1,167 @@
+package jwt
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// KeyProvider 按 kid 提供验签密钥，*KeySet 与 *RemoteVerifier 均实现了该接口。
+type KeyProvider interface {
+	VerificationKey(ctx context.Context, kid string) (*Key, error)
+}
+
+var (
+	_ KeyProvider = (*KeySet)(nil)
+	_ KeyProvider = (*RemoteVerifier)(nil)
+)
+
+// VerificationKey 实现 KeyProvider 接口。
+func (s *KeySet) VerificationKey(ctx context.Context, kid string) (*Key, error) {
+	key, ok := s.Lookup(kid)
+	if !ok {
+		return nil, fmt.Errorf("%w: kid %q", ErrKeyNotFound, kid)
+	}
+	return key, nil
+}
+
+// VerificationKey 实现 KeyProvider 接口，缓存过期或 kid 未知时重新拉取 JWKS。
+func (v *RemoteVerifier) VerificationKey(ctx context.Context, kid string) (*Key, error) {
+	return v.lookup(ctx, kid)
+}
+
+// IDTokenOption 是配置 IDTokenVerifier 的函数类型。
+type IDTokenOption func(*idTokenOptions)
+
+type idTokenOptions struct {
+	allowed []string
+	leeway  time.Duration
+	maxAge  time.Duration
+}
+
+// WithIDTokenAllowedAlgorithms 设置接受的签名算法白名单，为空时接受与密钥一致的算法。
+func WithIDTokenAllowedAlgorithms(algs ...string) IDTokenOption {
+	return func(o *idTokenOptions) {
+		o.allowed = algs
+	}
+}
+
+// WithIDTokenLeeway 设置时间类声明校验允许的时钟偏差。
+func WithIDTokenLeeway(leeway time.Duration) IDTokenOption {
+	return func(o *idTokenOptions) {
+		o.leeway = leeway
+	}
+}
+
+// WithIDTokenMaxAge 要求用户认证时间不早于 maxAge 之前，对应认证请求的 max_age 参数。
+// 设置后 ID 令牌必须包含 auth_time。
+func WithIDTokenMaxAge(maxAge time.Duration) IDTokenOption {
+	return func(o *idTokenOptions) {
+		o.maxAge = maxAge
+	}
+}
+
+// IDTokenVerifier 供依赖方（Relying Party）按 OpenID Connect Core §3.1.3.7 验证 ID 令牌。
+// IDTokenVerifier 是线程安全的。
+//
+//	keys := jwt.NewRemoteVerifier("https://auth.example.com/.well-known/jwks.json")
+//	verifier := jwt.NewIDTokenVerifier(keys, "https://auth.example.com", cfg.Goauth.ClientID)
+//	claims, err := verifier.Verify(ctx, idToken, savedNonce)
+//	if err == nil {
+//		err = claims.VerifyAccessToken(accessToken)
+//	}
+type IDTokenVerifier struct {
+	keys     KeyProvider
+	issuer   string
+	clientID string
+	opts     idTokenOptions
+}
+
+// NewIDTokenVerifier 创建 ID 令牌验证器，issuer 与 clientID 必须与令牌的 iss、aud 对应。
+func NewIDTokenVerifier(keys KeyProvider, issuer, clientID string, opts ...IDTokenOption) *IDTokenVerifier {
+	var o idTokenOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &IDTokenVerifier{keys: keys, issuer: issuer, clientID: clientID, opts: o}
+}
+
+// Verify 验证 ID 令牌的签名、iss、aud、azp、exp、iat，nonce 不为空时要求与令牌中的 nonce 一致。
+// 令牌过期时返回 ErrTokenExpired，其余校验失败返回包装了 ErrInvalidToken 的错误。
+func (v *IDTokenVerifier) Verify(ctx context.Context, rawIDToken, nonce string) (*IDTokenClaims, error) {
+	if v.clientID == "" {
+		return nil, ErrClientIDRequired
+	}
+
+	parserOpts := []jwt.ParserOption{
+		jwt.WithLeeway(v.opts.leeway),
+		jwt.WithIssuedAt(),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuer(v.issuer),
+		jwt.WithAudience(v.clientID),
+	}
+	if len(v.opts.allowed) > 0 {
+		parserOpts = append(parserOpts, jwt.WithValidMethods(v.opts.allowed))
+	}
+
+	token, err := jwt.ParseWithClaims(rawIDToken, &IDTokenClaims{}, keyFunc(func(kid string) (*Key, error) {
+		return v.keys.VerificationKey(ctx, kid)
+	}), parserOpts...)
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrTokenExpired
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	claims, ok := token.Claims.(*IDTokenClaims)
+	if !ok || !token.Valid {
+		return nil, ErrInvalidToken
+	}
+	claims.alg = token.Method.Alg()
+
+	if err := v.check(claims, nonce); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// check 校验 sub、azp、nonce 与 auth_time
+func (v *IDTokenVerifier) check(claims *IDTokenClaims, nonce string) error {
+	if claims.Subject == "" {
+		return fmt.Errorf("%w: missing sub", ErrInvalidToken)
+	}
+	if claims.IssuedAt == nil {
+		return fmt.Errorf("%w: missing iat", ErrInvalidToken)
+	}
+
+	// aud 包含多个值时必须携带 azp，携带 azp 时必须为本客户端
+	if len(claims.Audience) > 1 && claims.AuthorizedParty == "" {
+		return fmt.Errorf("%w: missing azp for multiple audiences", ErrInvalidToken)
+	}
+	if claims.AuthorizedParty != "" && claims.AuthorizedParty != v.clientID {
+		return fmt.Errorf("%w: azp mismatch", ErrInvalidToken)
+	}
+	if !slices.Contains(claims.Audience, v.clientID) {
+		return fmt.Errorf("%w: aud mismatch", ErrInvalidToken)
+	}
+
+	if nonce != "" && subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return fmt.Errorf("%w: nonce mismatch", ErrInvalidToken)
+	}
+
+	if v.opts.maxAge > 0 {
+		if claims.AuthTime == nil {
+			return fmt.Errorf("%w: missing auth_time", ErrInvalidToken)
+		}
+		if time.Since(claims.AuthTime.Time) > v.opts.maxAge+v.opts.leeway {
+			return fmt.Errorf("%w: authentication too old", ErrInvalidToken)
+		}
+	}
+	return nil
+}
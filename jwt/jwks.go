@@ -0,0 +1,161 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+)
+
+// JWK 表示 RFC 7517 JSON Web Key，仅包含公钥成员。
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+
+	// RSA 公钥成员
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// EC / OKP 公钥成员
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKS 表示 RFC 7517 JWK Set 文档。
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK 将密钥的公钥部分编码为 JWK，对称密钥无法公开，返回 false。
+func (k *Key) JWK() (JWK, bool) {
+	jwk := JWK{Kid: k.id, Use: "sig", Alg: k.Algorithm()}
+	switch pub := k.verifyKey.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = b64(pub.N.Bytes())
+		jwk.E = b64(big.NewInt(int64(pub.E)).Bytes())
+	case *ecdsa.PublicKey:
+		crv, x, y, err := ecParams(pub)
+		if err != nil {
+			return JWK{}, false
+		}
+		jwk.Kty, jwk.Crv, jwk.X, jwk.Y = "EC", crv, x, y
+	case ed25519.PublicKey:
+		jwk.Kty, jwk.Crv, jwk.X = "OKP", "Ed25519", b64(pub)
+	default:
+		return JWK{}, false
+	}
+	return jwk, true
+}
+
+// Key 将 JWK 解析为仅用于验签的公钥。
+// JWK 声明了 alg 时必须与密钥类型推断出的算法一致，否则返回 ErrInvalidKey。
+func (j JWK) Key() (*Key, error) {
+	var (
+		key *Key
+		err error
+	)
+	switch j.Kty {
+	case "RSA":
+		n, err1 := decodeBigInt(j.N)
+		e, err2 := decodeBigInt(j.E)
+		if err1 != nil || err2 != nil || !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("%w: malformed RSA JWK %q", ErrInvalidKey, j.Kid)
+		}
+		key, err = NewPublicKey(j.Kid, &rsa.PublicKey{N: n, E: int(e.Int64())})
+	case "EC":
+		var curve elliptic.Curve
+		switch j.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("%w: unsupported EC curve %q", ErrInvalidKey, j.Crv)
+		}
+		x, err1 := base64.RawURLEncoding.DecodeString(j.X)
+		y, err2 := base64.RawURLEncoding.DecodeString(j.Y)
+		if err1 != nil || err2 != nil {
+			return nil, fmt.Errorf("%w: malformed EC JWK %q", ErrInvalidKey, j.Kid)
+		}
+		pub, perr := ecdsa.ParseUncompressedPublicKey(curve, append(append([]byte{4}, x...), y...))
+		if perr != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidKey, perr)
+		}
+		key, err = NewPublicKey(j.Kid, pub)
+	case "OKP":
+		x, derr := base64.RawURLEncoding.DecodeString(j.X)
+		if j.Crv != "Ed25519" || derr != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("%w: malformed OKP JWK %q", ErrInvalidKey, j.Kid)
+		}
+		key, err = NewPublicKey(j.Kid, ed25519.PublicKey(x))
+	default:
+		return nil, fmt.Errorf("%w: unsupported kty %q", ErrInvalidKey, j.Kty)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if j.Alg != "" && j.Alg != key.Algorithm() {
+		return nil, fmt.Errorf("%w: alg %s does not match key", ErrInvalidKey, j.Alg)
+	}
+	return key, nil
+}
+
+// JWKS 返回密钥集中全部未退役非对称密钥的公钥，对称密钥不会公开。
+func (s *KeySet) JWKS() JWKS {
+	set := JWKS{Keys: []JWK{}}
+	for _, k := range s.Keys() {
+		if jwk, ok := k.JWK(); ok {
+			set.Keys = append(set.Keys, jwk)
+		}
+	}
+	return set
+}
+
+// JWKS 返回访问令牌的公钥集合，供其他服务验证访问令牌。
+// 未配置访问令牌密钥或仅使用对称密钥时返回空集合。
+func (m *Manager) JWKS() JWKS {
+	if m.access == nil {
+		return JWKS{Keys: []JWK{}}
+	}
+	return m.access.JWKS()
+}
+
+// JWKSHandler 返回输出 JWKS 文档的 http.Handler，每次请求都反映密钥集的最新状态。
+//
+//	r.GET("/.well-known/jwks.json", gin.WrapH(jwtMgr.JWKSHandler()))
+func (m *Manager) JWKSHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		_ = json.NewEncoder(w).Encode(m.JWKS())
+	})
+}
+
+// decodeBigInt 解码 base64url 编码的大端无符号整数
+func decodeBigInt(s string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errors.New("empty integer")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
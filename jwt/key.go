@@ -0,0 +1,270 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"slices"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// 支持的签名算法。
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+	AlgES256 = "ES256"
+	AlgES384 = "ES384"
+	AlgES512 = "ES512"
+	AlgEdDSA = "EdDSA"
+)
+
+// Key 表示一把签名或验签密钥及其 kid。
+// Key 创建后不可变，可在多个 KeySet 与 goroutine 间共享。
+type Key struct {
+	id        string
+	method    jwt.SigningMethod
+	signKey   any
+	verifyKey any
+}
+
+// NewSecretKey 创建 HS256 对称密钥。
+func NewSecretKey(id, secret string) (*Key, error) {
+	if secret == "" {
+		return nil, ErrInvalidSecret
+	}
+	return &Key{
+		id:        id,
+		method:    jwt.SigningMethodHS256,
+		signKey:   []byte(secret),
+		verifyKey: []byte(secret),
+	}, nil
+}
+
+// NewPrivateKey 创建非对称签名密钥，签名算法由私钥类型推断，公钥由私钥导出。
+// id 为空时使用公钥的 RFC 7638 指纹作为 kid。
+func NewPrivateKey(id string, priv crypto.Signer) (*Key, error) {
+	if priv == nil {
+		return nil, fmt.Errorf("%w: nil private key", ErrInvalidKey)
+	}
+	k, err := NewPublicKey(id, priv.Public())
+	if err != nil {
+		return nil, err
+	}
+	k.signKey = priv
+	return k, nil
+}
+
+// NewPublicKey 创建仅用于验签的公钥。
+// id 为空时使用公钥的 RFC 7638 指纹作为 kid。
+func NewPublicKey(id string, pub crypto.PublicKey) (*Key, error) {
+	method, err := methodForPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+	if id == "" {
+		if id, err = thumbprint(pub); err != nil {
+			return nil, err
+		}
+	}
+	return &Key{id: id, method: method, verifyKey: pub}, nil
+}
+
+// ParsePrivateKeyPEM 从 PEM 数据创建非对称签名密钥。
+// 支持 PKCS#8、PKCS#1（RSA）与 SEC 1（EC）格式。
+func ParsePrivateKeyPEM(id string, data []byte) (*Key, error) {
+	priv, err := decodePrivateKeyPEM(data)
+	if err != nil {
+		return nil, err
+	}
+	return NewPrivateKey(id, priv)
+}
+
+// ParsePublicKeyPEM 从 PEM 数据创建验签公钥。
+// 支持 PKIX、PKCS#1（RSA）公钥与 X.509 证书。
+func ParsePublicKeyPEM(id string, data []byte) (*Key, error) {
+	pub, err := decodePublicKeyPEM(data)
+	if err != nil {
+		return nil, err
+	}
+	return NewPublicKey(id, pub)
+}
+
+// ID 返回 kid，签发令牌时写入 header，为空时不写入。
+func (k *Key) ID() string {
+	return k.id
+}
+
+// Algorithm 返回 JWS 算法名，如 "RS256"。
+func (k *Key) Algorithm() string {
+	return k.method.Alg()
+}
+
+// CanSign 返回是否持有签名密钥。
+func (k *Key) CanSign() bool {
+	return k != nil && k.signKey != nil
+}
+
+// PublicKey 返回非对称密钥的公钥，对称密钥返回 nil。
+func (k *Key) PublicKey() crypto.PublicKey {
+	if _, ok := k.verifyKey.([]byte); ok {
+		return nil
+	}
+	return k.verifyKey
+}
+
+// methodForPublicKey 根据公钥类型选择签名算法：
+// RSA 使用 RS256，ECDSA 按曲线使用 ES256/ES384/ES512，Ed25519 使用 EdDSA。
+func methodForPublicKey(pub crypto.PublicKey) (jwt.SigningMethod, error) {
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		return jwt.SigningMethodRS256, nil
+	case *ecdsa.PublicKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return jwt.SigningMethodES256, nil
+		case elliptic.P384():
+			return jwt.SigningMethodES384, nil
+		case elliptic.P521():
+			return jwt.SigningMethodES512, nil
+		}
+		return nil, fmt.Errorf("%w: unsupported ecdsa curve %s", ErrInvalidKey, k.Curve.Params().Name)
+	case ed25519.PublicKey:
+		return jwt.SigningMethodEdDSA, nil
+	}
+	return nil, fmt.Errorf("%w: unsupported key type %T", ErrInvalidKey, pub)
+}
+
+// thumbprint 计算公钥的 RFC 7638 JWK 指纹（SHA-256，base64url 编码）。
+// 必需成员按字典序排列，序列化结果与 JSON 编码一致。
+func thumbprint(pub crypto.PublicKey) (string, error) {
+	var canonical string
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		canonical = fmt.Sprintf(`{"e":%q,"kty":"RSA","n":%q}`,
+			b64(big.NewInt(int64(k.E)).Bytes()), b64(k.N.Bytes()))
+	case *ecdsa.PublicKey:
+		crv, x, y, err := ecParams(k)
+		if err != nil {
+			return "", err
+		}
+		canonical = fmt.Sprintf(`{"crv":%q,"kty":"EC","x":%q,"y":%q}`, crv, x, y)
+	case ed25519.PublicKey:
+		canonical = fmt.Sprintf(`{"crv":"Ed25519","kty":"OKP","x":%q}`, b64(k))
+	default:
+		return "", fmt.Errorf("%w: unsupported key type %T", ErrInvalidKey, pub)
+	}
+	sum := sha256.Sum256([]byte(canonical))
+	return b64(sum[:]), nil
+}
+
+// ecParams 返回 EC 公钥的曲线名与按曲线长度补齐的坐标。
+func ecParams(k *ecdsa.PublicKey) (crv, x, y string, err error) {
+	size := (k.Curve.Params().BitSize + 7) / 8
+	switch k.Curve {
+	case elliptic.P256():
+		crv = "P-256"
+	case elliptic.P384():
+		crv = "P-384"
+	case elliptic.P521():
+		crv = "P-521"
+	default:
+		return "", "", "", fmt.Errorf("%w: unsupported ecdsa curve %s", ErrInvalidKey, k.Curve.Params().Name)
+	}
+	return crv, b64(k.X.FillBytes(make([]byte, size))), b64(k.Y.FillBytes(make([]byte, size))), nil
+}
+
+// b64 使用无填充的 base64url 编码。
+func b64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodePrivateKeyPEM 解析 PEM 编码的私钥。
+func decodePrivateKeyPEM(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%w: no PEM block found", ErrInvalidKey)
+	}
+
+	var (
+		key any
+		err error
+	)
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKey, err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported key type %T", ErrInvalidKey, key)
+	}
+	return signer, nil
+}
+
+// decodePublicKeyPEM 解析 PEM 编码的公钥。
+func decodePublicKeyPEM(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%w: no PEM block found", ErrInvalidKey)
+	}
+
+	switch block.Type {
+	case "RSA PUBLIC KEY":
+		key, err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidKey, err)
+		}
+		return key, nil
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidKey, err)
+		}
+		return cert.PublicKey, nil
+	default:
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidKey, err)
+		}
+		return key, nil
+	}
+}
+
+// readKeyFile 读取密钥文件。
+func readKeyFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: read %s: %v", ErrInvalidKey, path, err)
+	}
+	return data, nil
+}
+
+// checkAllowed 检查密钥集中所有密钥的算法是否在允许列表中，allowed 为空表示不限制。
+func checkAllowed(set *KeySet, allowed []string) error {
+	if set == nil || len(allowed) == 0 {
+		return nil
+	}
+	for _, k := range set.Keys() {
+		if !slices.Contains(allowed, k.Algorithm()) {
+			return fmt.Errorf("%w: %s", ErrAlgorithmNotAllowed, k.Algorithm())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package jwt
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// KeySet 保存一把当前签名密钥（active）与若干仅用于验签的密钥，按 kid 选择验签密钥。
+// KeySet 是线程安全的，可在运行时增删密钥（例如配置热更新），无需重启或重建 Manager。
+//
+// 密钥轮换：调用 Rotate 切换签名密钥后，旧密钥转为仅验签并保留 overlap 时长，
+// 期间旧密钥签发的令牌仍可通过验证。overlap 应不小于对应令牌的有效期
+// （访问令牌为 AccessTTL，刷新令牌为 RefreshTTL），否则尚未过期的令牌会提前失效。
+//
+//	next, _ := jwt.ParsePrivateKeyPEM("2025-06", pemData)
+//	_ = jwtMgr.AccessKeySet().Rotate(next, jwt.DefaultAccessTTL)
+type KeySet struct {
+	mu     sync.RWMutex
+	active *Key
+	keys   map[string]keyEntry
+}
+
+// keyEntry 密钥及其退役时间，retireAt 为零值表示不过期
+type keyEntry struct {
+	key      *Key
+	retireAt time.Time
+}
+
+// NewKeySet 创建密钥集。
+// active 为签名密钥，可为 nil（仅验签的密钥集）；verifyOnly 为额外的验签密钥。
+// 同一 kid 只能出现一次，否则返回 ErrDuplicateKeyID。
+func NewKeySet(active *Key, verifyOnly ...*Key) (*KeySet, error) {
+	s := &KeySet{keys: make(map[string]keyEntry)}
+	if active != nil {
+		if !active.CanSign() {
+			return nil, ErrSigningKeyNotConfigured
+		}
+		s.active = active
+		s.keys[active.id] = keyEntry{key: active}
+	}
+	for _, k := range verifyOnly {
+		if _, ok := s.keys[k.id]; ok {
+			return nil, ErrDuplicateKeyID
+		}
+		s.keys[k.id] = keyEntry{key: k}
+	}
+	if len(s.keys) == 0 {
+		return nil, ErrInvalidSecret
+	}
+	return s, nil
+}
+
+// Active 返回当前签名密钥，未配置时返回 nil。
+func (s *KeySet) Active() *Key {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.active
+}
+
+// Lookup 按 kid 查找验签密钥，已退役的密钥视为不存在。
+// kid 为空（令牌 header 未携带 kid）且集合中没有空 kid 的密钥时，返回当前签名密钥。
+func (s *KeySet) Lookup(kid string) (*Key, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if e, ok := s.keys[kid]; ok && !e.retired(time.Now()) {
+		return e.key, true
+	}
+	if kid == "" && s.active != nil {
+		return s.active, true
+	}
+	return nil, false
+}
+
+// Keys 返回全部未退役的密钥（含签名密钥），按 kid 排序。
+func (s *KeySet) Keys() []*Key {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	keys := make([]*Key, 0, len(s.keys))
+	for _, e := range s.keys {
+		if !e.retired(now) {
+			keys = append(keys, e.key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].id < keys[j].id })
+	return keys
+}
+
+// Add 添加或替换一把仅验签的密钥。
+// kid 与当前签名密钥相同时返回 ErrKeyInUse。
+func (s *KeySet) Add(k *Key) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.active != nil && s.active.id == k.id {
+		return ErrKeyInUse
+	}
+	s.prune()
+	s.keys[k.id] = keyEntry{key: k}
+	return nil
+}
+
+// Remove 移除指定 kid 的密钥，之后该密钥签发的令牌将无法通过验证。
+// 不能移除当前签名密钥（返回 ErrKeyInUse），kid 不存在时返回 ErrKeyNotFound。
+func (s *KeySet) Remove(kid string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.active != nil && s.active.id == kid {
+		return ErrKeyInUse
+	}
+	if _, ok := s.keys[kid]; !ok {
+		return ErrKeyNotFound
+	}
+	delete(s.keys, kid)
+	return nil
+}
+
+// Rotate 将 next 设为新的签名密钥，原签名密钥转为仅验签并在 overlap 后自动退役。
+// overlap <= 0 表示旧密钥一直保留，直到显式调用 Remove。
+// next 必须持有私钥且 kid 与原签名密钥不同，否则分别返回 ErrSigningKeyNotConfigured 与 ErrDuplicateKeyID。
+func (s *KeySet) Rotate(next *Key, overlap time.Duration) error {
+	if !next.CanSign() {
+		return ErrSigningKeyNotConfigured
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prune()
+	if prev := s.active; prev != nil {
+		if prev.id == next.id {
+			return ErrDuplicateKeyID
+		}
+		var retireAt time.Time
+		if overlap > 0 {
+			retireAt = time.Now().Add(overlap)
+		}
+		s.keys[prev.id] = keyEntry{key: prev, retireAt: retireAt}
+	}
+	s.active = next
+	s.keys[next.id] = keyEntry{key: next}
+	return nil
+}
+
+// prune 清理已退役的密钥，调用方需持有写锁
+func (s *KeySet) prune() {
+	now := time.Now()
+	for id, e := range s.keys {
+		if e.retired(now) {
+			delete(s.keys, id)
+		}
+	}
+}
+
+// retired 返回密钥在 now 时是否已退役
+func (e keyEntry) retired(now time.Time) bool {
+	return !e.retireAt.IsZero() && now.After(e.retireAt)
+}
@@ -2,13 +2,25 @@ package jwt
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 // Manager 提供 JWT 令牌的生成、解析与刷新功能。
 // Manager 是线程安全的，可在多个 goroutine 中共享使用。
 type Manager struct {
 	opts *Options
+
+	// access、refresh 分别为访问令牌与刷新令牌的密钥集，未配置时为 nil
+	access  *KeySet
+	refresh *KeySet
+
+	// accessRules、refreshRules 分别为访问令牌与刷新令牌的解析校验规则
+	accessRules  *validation
+	refreshRules *validation
 }
 
 // SetExtraResolver 设置刷新时加载用户信息的回调。
@@ -39,36 +51,143 @@ func (m *Manager) SetExtraResolver(resolver ExtraResolver) {
 //   - 同时配置两者：完整功能
 //
 // 可通过 WithSecret 同时设置两者，或分别使用 WithAccessSecret 和 WithRefreshSecret。
+//
+// 访问令牌也可使用非对称密钥签名（WithAccessPrivateKey 等），此时 AccessSecret 不再用于访问令牌；
+// 仅配置 AccessPublicKey 时 Manager 只能解析访问令牌。
+// 需要密钥轮换时使用 WithAccessKeySet / WithRefreshKeySet 配置带 kid 的密钥集。
 func NewManager(opts ...Option) (*Manager, error) {
 	o := defaultOptions()
 	for _, opt := range opts {
 		opt(o)
 	}
+	if o.err != nil {
+		return nil, o.err
+	}
+
+	access, err := accessKeySet(o)
+	if err != nil {
+		return nil, err
+	}
+	refresh, err := refreshKeySet(o, access)
+	if err != nil {
+		return nil, err
+	}
 
-	if o.AccessSecret == "" && o.RefreshSecret == "" {
+	if access == nil && refresh == nil {
 		return nil, ErrInvalidSecret
 	}
+	if err := checkAllowed(access, o.AllowedAlgorithms); err != nil {
+		return nil, err
+	}
+
+	m := &Manager{opts: o, access: access, refresh: refresh}
+	m.refreshRules = m.rules()
+	m.accessRules = m.rules()
+	m.accessRules.allowed = o.AllowedAlgorithms
+	return m, nil
+}
+
+// rules 根据配置构建解析校验规则
+func (m *Manager) rules() *validation {
+	v := &validation{
+		audience: m.opts.Audience,
+		leeway:   m.opts.Leeway,
+		required: m.opts.RequiredClaims,
+		validate: m.opts.Validator,
+	}
+	if m.opts.VerifyIssuer {
+		v.issuer = m.opts.Issuer
+	}
+	return v
+}
+
+// AccessKeySet 返回访问令牌密钥集，可用于运行时轮换或增删验签密钥；未配置时返回 nil。
+func (m *Manager) AccessKeySet() *KeySet {
+	return m.access
+}
+
+// RefreshKeySet 返回刷新令牌密钥集；未配置时返回 nil。
+// 使用 WithSecret 配置时与 AccessKeySet 为同一密钥集。
+func (m *Manager) RefreshKeySet() *KeySet {
+	return m.refresh
+}
+
+// accessKeySet 按优先级构建访问令牌密钥集：
+// AccessKeySet > AccessPrivateKey > AccessPublicKey > AccessSecret
+func accessKeySet(o *Options) (*KeySet, error) {
+	switch {
+	case o.AccessKeySet != nil:
+		return o.AccessKeySet, nil
+	case o.AccessPrivateKey != nil:
+		key, err := NewPrivateKey("", o.AccessPrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		return NewKeySet(key)
+	case o.AccessPublicKey != nil:
+		key, err := NewPublicKey("", o.AccessPublicKey)
+		if err != nil {
+			return nil, err
+		}
+		return NewKeySet(nil, key)
+	case o.AccessSecret != "":
+		key, err := NewSecretKey("", o.AccessSecret)
+		if err != nil {
+			return nil, err
+		}
+		return NewKeySet(key)
+	}
+	return nil, nil
+}
+
+// refreshKeySet 构建刷新令牌密钥集，RefreshSecret 与 AccessSecret 相同时复用访问令牌密钥集
+func refreshKeySet(o *Options, access *KeySet) (*KeySet, error) {
+	switch {
+	case o.RefreshKeySet != nil:
+		return o.RefreshKeySet, nil
+	case o.RefreshSecret == "":
+		return nil, nil
+	case o.RefreshSecret == o.AccessSecret && o.AccessKeySet == nil &&
+		o.AccessPrivateKey == nil && o.AccessPublicKey == nil:
+		return access, nil
+	}
 
-	return &Manager{opts: o}, nil
+	key, err := NewSecretKey("", o.RefreshSecret)
+	if err != nil {
+		return nil, err
+	}
+	return NewKeySet(key)
 }
 
 // GenerateAccessToken 生成访问令牌。
 // subject: 用户唯一标识（对应 JWT 标准的 sub 字段）
 // extra: 自定义扩展字段（如角色、权限等）
 //
-// 若未配置 AccessSecret，返回 ErrAccessSecretNotConfigured。
+// 若未配置访问令牌密钥，返回 ErrAccessSecretNotConfigured；
+// 仅配置了验签公钥时返回 ErrSigningKeyNotConfigured。
 func (m *Manager) GenerateAccessToken(subject string, extra map[string]any) (string, error) {
-	if m.opts.AccessSecret == "" {
+	return m.generateAccess(subject, extra, Grant{})
+}
+
+// generateAccess 生成携带授权信息的访问令牌
+func (m *Manager) generateAccess(subject string, extra map[string]any, grant Grant) (string, error) {
+	if m.access == nil {
 		return "", ErrAccessSecretNotConfigured
 	}
-	return generateToken(
-		m.opts.AccessSecret,
+	key := m.access.Active()
+	if key == nil {
+		return "", ErrSigningKeyNotConfigured
+	}
+	claims := newClaims(
 		m.opts.Issuer,
+		m.opts.Audience,
 		m.opts.AccessTTL,
 		AccessToken,
 		subject,
 		extra,
 	)
+	grant.apply(claims)
+	return signToken(key, claims)
 }
 
 // GenerateRefreshToken 生成刷新令牌。
@@ -76,28 +195,58 @@ func (m *Manager) GenerateAccessToken(subject string, extra map[string]any) (str
 //
 // 若未配置 RefreshSecret，返回 ErrRefreshSecretNotConfigured。
 func (m *Manager) GenerateRefreshToken(subject string) (string, error) {
-	if m.opts.RefreshSecret == "" {
+	return m.GenerateRefreshTokenContext(context.Background(), subject)
+}
+
+// GenerateRefreshTokenContext 与 GenerateRefreshToken 相同，ctx 用于写入家族存储。
+// 启用刷新令牌轮换时，每次调用都会创建一个新的令牌家族（通常对应一次登录）。
+func (m *Manager) GenerateRefreshTokenContext(ctx context.Context, subject string) (string, error) {
+	return m.generateRefresh(ctx, subject, Grant{})
+}
+
+// generateRefresh 生成携带授权信息的刷新令牌
+func (m *Manager) generateRefresh(ctx context.Context, subject string, grant Grant) (string, error) {
+	if m.refresh == nil {
 		return "", ErrRefreshSecretNotConfigured
 	}
-	return generateToken(
-		m.opts.RefreshSecret,
+
+	claims := newClaims(
 		m.opts.Issuer,
+		m.opts.Audience,
 		m.opts.RefreshTTL,
 		RefreshToken,
 		subject,
 		nil, // 不写入 extra
 	)
+	grant.apply(claims)
+	if store := m.opts.FamilyStore; store != nil {
+		claims.FamilyID = uuid.NewString()
+		if err := store.Create(ctx, claims.FamilyID, claims.ID, m.opts.RefreshTTL); err != nil {
+			return "", fmt.Errorf("jwt: create token family: %w", err)
+		}
+	}
+	return m.signRefresh(claims)
 }
 
 // GenerateTokenPair 同时生成访问令牌和刷新令牌。
 // 返回 (accessToken, refreshToken, error)。
 func (m *Manager) GenerateTokenPair(subject string, extra map[string]any) (string, string, error) {
-	accessToken, err := m.GenerateAccessToken(subject, extra)
+	return m.GenerateTokenPairContext(context.Background(), subject, extra)
+}
+
+// GenerateTokenPairContext 与 GenerateTokenPair 相同，ctx 用于写入家族存储。
+func (m *Manager) GenerateTokenPairContext(ctx context.Context, subject string, extra map[string]any) (string, string, error) {
+	return m.generatePair(ctx, subject, extra, Grant{})
+}
+
+// generatePair 生成携带授权信息的令牌对
+func (m *Manager) generatePair(ctx context.Context, subject string, extra map[string]any, grant Grant) (string, string, error) {
+	accessToken, err := m.generateAccess(subject, extra, grant)
 	if err != nil {
 		return "", "", fmt.Errorf("generate access token: %w", err)
 	}
 
-	refreshToken, err := m.GenerateRefreshToken(subject)
+	refreshToken, err := m.generateRefresh(ctx, subject, grant)
 	if err != nil {
 		return "", "", fmt.Errorf("generate refresh token: %w", err)
 	}
@@ -106,44 +255,46 @@ func (m *Manager) GenerateTokenPair(subject string, extra map[string]any) (strin
 }
 
 // ParseToken 尝试解析令牌并返回 Claims。
-// 根据已配置的密钥尝试解析，优先使用 AccessSecret。
+// 根据已配置的密钥尝试解析，优先使用访问令牌密钥；
+// 仅接受访问令牌与刷新令牌，ID 令牌等其他类型返回 ErrInvalidTokenType。
 // 推荐使用 ParseAccessToken 或 ParseRefreshToken 以明确令牌类型。
 func (m *Manager) ParseToken(tokenString string) (*Claims, error) {
-	var lastErr error
+	return m.ParseTokenContext(context.Background(), tokenString)
+}
 
-	// 尝试用 AccessSecret 解析
-	if m.opts.AccessSecret != "" {
-		claims, err := parseToken(tokenString, m.opts.AccessSecret)
-		if err == nil {
-			return claims, nil
-		}
-		lastErr = err
+// ParseTokenContext 与 ParseToken 相同，ctx 用于查询撤销状态。
+// 令牌为刷新令牌时与 ParseRefreshTokenContext 一样检查家族状态。
+func (m *Manager) ParseTokenContext(ctx context.Context, tokenString string) (*Claims, error) {
+	claims, err := m.parseAny(tokenString)
+	if err != nil {
+		return nil, err
 	}
-
-	// 若 RefreshSecret 存在且与 AccessSecret 不同，尝试解析
-	if m.opts.RefreshSecret != "" && m.opts.RefreshSecret != m.opts.AccessSecret {
-		claims, err := parseToken(tokenString, m.opts.RefreshSecret)
-		if err == nil {
-			return claims, nil
-		}
-		lastErr = err
+	if err := m.checkRevoked(ctx, claims); err != nil {
+		return nil, err
 	}
-
-	if lastErr != nil {
-		return nil, lastErr
+	if claims.TokenType == RefreshToken {
+		if err := m.checkFamily(ctx, claims); err != nil {
+			return nil, err
+		}
 	}
-	return nil, ErrInvalidToken
+	return claims, nil
 }
 
 // ParseAccessToken 解析访问令牌，若令牌类型不是 access 则返回错误。
 //
-// 若未配置 AccessSecret，返回 ErrAccessSecretNotConfigured。
+// 若未配置访问令牌密钥，返回 ErrAccessSecretNotConfigured。
 func (m *Manager) ParseAccessToken(tokenString string) (*Claims, error) {
-	if m.opts.AccessSecret == "" {
+	return m.ParseAccessTokenContext(context.Background(), tokenString)
+}
+
+// ParseAccessTokenContext 与 ParseAccessToken 相同，ctx 用于查询撤销状态。
+// 配置了 Revoker 且令牌已被撤销时返回 ErrTokenRevoked。
+func (m *Manager) ParseAccessTokenContext(ctx context.Context, tokenString string) (*Claims, error) {
+	if m.access == nil {
 		return nil, ErrAccessSecretNotConfigured
 	}
 
-	claims, err := parseToken(tokenString, m.opts.AccessSecret)
+	claims, err := parseToken(tokenString, m.access, m.accessRules)
 	if err != nil {
 		return nil, err
 	}
@@ -152,6 +303,10 @@ func (m *Manager) ParseAccessToken(tokenString string) (*Claims, error) {
 		return nil, ErrInvalidTokenType
 	}
 
+	if err := m.checkRevoked(ctx, claims); err != nil {
+		return nil, err
+	}
+
 	return claims, nil
 }
 
@@ -159,11 +314,31 @@ func (m *Manager) ParseAccessToken(tokenString string) (*Claims, error) {
 //
 // 若未配置 RefreshSecret，返回 ErrRefreshSecretNotConfigured。
 func (m *Manager) ParseRefreshToken(tokenString string) (*Claims, error) {
-	if m.opts.RefreshSecret == "" {
+	return m.ParseRefreshTokenContext(context.Background(), tokenString)
+}
+
+// ParseRefreshTokenContext 与 ParseRefreshToken 相同，ctx 用于查询撤销状态。
+// 配置了 Revoker 且令牌已被撤销时返回 ErrTokenRevoked；
+// 启用刷新令牌轮换时，令牌已被轮换替换或所属家族已撤销同样返回 ErrTokenRevoked。
+// 该方法只读取家族状态，重用检测与家族撤销仅在 RefreshTokenPair 中进行。
+func (m *Manager) ParseRefreshTokenContext(ctx context.Context, tokenString string) (*Claims, error) {
+	claims, err := m.parseRefresh(ctx, tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.checkFamily(ctx, claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// parseRefresh 解析刷新令牌并检查撤销状态，不检查家族状态
+func (m *Manager) parseRefresh(ctx context.Context, tokenString string) (*Claims, error) {
+	if m.refresh == nil {
 		return nil, ErrRefreshSecretNotConfigured
 	}
 
-	claims, err := parseToken(tokenString, m.opts.RefreshSecret)
+	claims, err := parseToken(tokenString, m.refresh, m.refreshRules)
 	if err != nil {
 		return nil, err
 	}
@@ -172,6 +347,10 @@ func (m *Manager) ParseRefreshToken(tokenString string) (*Claims, error) {
 		return nil, ErrInvalidTokenType
 	}
 
+	if err := m.checkRevoked(ctx, claims); err != nil {
+		return nil, err
+	}
+
 	return claims, nil
 }
 
@@ -184,14 +363,19 @@ func (m *Manager) ValidateToken(tokenString string) error {
 // RefreshAccessToken 使用刷新令牌生成新的访问令牌。
 // 此方法需要配置 ExtraResolver，用于根据 subject 加载最新的扩展信息。
 // 若未配置 resolver，返回 ErrResolverNotConfigured。
+// 启用刷新令牌轮换时刷新令牌不可重复使用，返回 ErrRotationEnabled，应改用 RefreshTokenPair。
 func (m *Manager) RefreshAccessToken(ctx context.Context, refreshToken string) (string, error) {
+	if m.opts.FamilyStore != nil {
+		return "", ErrRotationEnabled
+	}
+
 	// 检查 resolver 是否已配置
 	if m.opts.Resolver == nil {
 		return "", ErrResolverNotConfigured
 	}
 
 	// 解析刷新令牌
-	claims, err := m.ParseRefreshToken(refreshToken)
+	claims, err := m.ParseRefreshTokenContext(ctx, refreshToken)
 	if err != nil {
 		return "", err
 	}
@@ -208,3 +392,211 @@ func (m *Manager) RefreshAccessToken(ctx context.Context, refreshToken string) (
 	// 生成新的访问令牌
 	return m.GenerateAccessToken(subject, extra)
 }
+
+// RefreshTokenPair 使用刷新令牌换取新的访问令牌与刷新令牌。
+// 此方法需要配置 ExtraResolver，若未配置返回 ErrResolverNotConfigured。
+//
+// 启用刷新令牌轮换时，新刷新令牌与旧令牌属于同一家族，旧令牌随即失效；
+// 已使用过的刷新令牌被再次提交时整个家族被撤销并返回 ErrRefreshTokenReused，
+// 家族已撤销或过期时返回 ErrTokenRevoked。
+// 未启用轮换时仅签发新的令牌对，旧刷新令牌在过期前仍可使用。
+func (m *Manager) RefreshTokenPair(ctx context.Context, refreshToken string) (string, string, error) {
+	return m.refreshPair(ctx, refreshToken, "")
+}
+
+// refreshPair 刷新令牌对，新令牌沿用旧刷新令牌的授权信息；scope 不为空时新访问令牌使用该 scope
+func (m *Manager) refreshPair(ctx context.Context, refreshToken, scope string) (string, string, error) {
+	if m.opts.Resolver == nil {
+		return "", "", ErrResolverNotConfigured
+	}
+
+	// 家族状态交由 Rotate 判断，使已轮换的旧令牌能够触发重用检测
+	claims, err := m.parseRefresh(ctx, refreshToken)
+	if err != nil {
+		return "", "", err
+	}
+	subject := claims.Subject
+
+	extra, err := m.opts.Resolver.ResolveExtra(ctx, subject)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %v", ErrResolveFailed, err)
+	}
+
+	grant := Grant{ClientID: claims.ClientID, Scope: claims.Scope}
+	accessGrant := grant
+	if scope != "" {
+		accessGrant.Scope = scope
+	}
+
+	store := m.opts.FamilyStore
+	if store == nil {
+		accessToken, err := m.generateAccess(subject, extra, accessGrant)
+		if err != nil {
+			return "", "", fmt.Errorf("generate access token: %w", err)
+		}
+		newRefreshToken, err := m.generateRefresh(ctx, subject, grant)
+		if err != nil {
+			return "", "", fmt.Errorf("generate refresh token: %w", err)
+		}
+		return accessToken, newRefreshToken, nil
+	}
+
+	if claims.FamilyID == "" {
+		return "", "", fmt.Errorf("%w: missing token family", ErrInvalidToken)
+	}
+	next := newClaims(m.opts.Issuer, m.opts.Audience, m.opts.RefreshTTL, RefreshToken, subject, nil)
+	next.FamilyID = claims.FamilyID
+	grant.apply(next)
+
+	// 先完成签名再轮换，避免签名失败时家族指向从未签发的 jti，导致旧令牌被误判为重用
+	accessToken, err := m.generateAccess(subject, extra, accessGrant)
+	if err != nil {
+		return "", "", fmt.Errorf("generate access token: %w", err)
+	}
+	newRefreshToken, err := m.signRefresh(next)
+	if err != nil {
+		return "", "", fmt.Errorf("generate refresh token: %w", err)
+	}
+	if err := store.Rotate(ctx, claims.FamilyID, claims.ID, next.ID, m.opts.RefreshTTL); err != nil {
+		return "", "", err
+	}
+	return accessToken, newRefreshToken, nil
+}
+
+// signRefresh 使用当前刷新令牌签名密钥签名声明
+func (m *Manager) signRefresh(claims *Claims) (string, error) {
+	if m.refresh == nil {
+		return "", ErrRefreshSecretNotConfigured
+	}
+	key := m.refresh.Active()
+	if key == nil {
+		return "", ErrSigningKeyNotConfigured
+	}
+	return signToken(key, claims)
+}
+
+// RevokeToken 撤销单个令牌（access 或 refresh），撤销记录保留到令牌自然过期。
+// 启用刷新令牌轮换时，撤销刷新令牌会同时撤销其所属家族。
+// 已过期的令牌无需撤销，直接返回 nil；未配置 Revoker 时返回 ErrRevokerNotConfigured。
+func (m *Manager) RevokeToken(ctx context.Context, tokenString string) error {
+	if m.opts.Revoker == nil {
+		return ErrRevokerNotConfigured
+	}
+
+	claims, err := m.parseAny(tokenString)
+	if errors.Is(err, ErrTokenExpired) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if claims.ID == "" || claims.ExpiresAt == nil {
+		return fmt.Errorf("%w: missing jti or exp", ErrInvalidToken)
+	}
+
+	if err := m.opts.Revoker.Revoke(ctx, claims.ID, claims.ExpiresAt.Time); err != nil {
+		return err
+	}
+	// 撤销启用轮换的刷新令牌时一并撤销其家族，使同一登录后续轮换出的令牌同样失效
+	if claims.FamilyID != "" && m.opts.FamilyStore != nil {
+		return m.opts.FamilyStore.Revoke(ctx, claims.FamilyID)
+	}
+	return nil
+}
+
+// RevokeAllForSubject 撤销 subject 此前签发的全部令牌，适用于修改密码、强制下线等场景。
+// 令牌 iat 精确到秒，撤销时刻所在秒之前签发的令牌失效；
+// 同一秒内签发的令牌（如修改密码后为当前会话重新签发的令牌）仍然有效。
+// 未配置 Revoker 时返回 ErrRevokerNotConfigured。
+func (m *Manager) RevokeAllForSubject(ctx context.Context, subject string) error {
+	if m.opts.Revoker == nil {
+		return ErrRevokerNotConfigured
+	}
+	return m.opts.Revoker.RevokeSubject(ctx, subject, time.Now(), max(m.opts.AccessTTL, m.opts.RefreshTTL))
+}
+
+// parseAny 依次尝试访问令牌与刷新令牌密钥解析令牌，不检查撤销状态。
+// 令牌类型既不是 access 也不是 refresh 时（如同样以访问令牌密钥签名的 ID 令牌）返回 ErrInvalidTokenType。
+func (m *Manager) parseAny(tokenString string) (*Claims, error) {
+	var lastErr error
+
+	// 尝试用访问令牌密钥解析
+	if m.access != nil {
+		claims, err := parseTyped(tokenString, m.access, m.accessRules)
+		if err == nil {
+			return claims, nil
+		}
+		lastErr = err
+	}
+
+	// 若刷新令牌密钥存在且与访问令牌密钥不同，尝试解析
+	if m.refresh != nil && m.refresh != m.access {
+		claims, err := parseTyped(tokenString, m.refresh, m.refreshRules)
+		if err == nil {
+			return claims, nil
+		}
+		lastErr = err
+	}
+
+	if lastErr != nil {
+		return nil, lastErr
+	}
+	return nil, ErrInvalidToken
+}
+
+// parseTyped 解析令牌并要求令牌类型为 access 或 refresh
+func parseTyped(tokenString string, keys *KeySet, rules *validation) (*Claims, error) {
+	claims, err := parseToken(tokenString, keys, rules)
+	if err != nil {
+		return nil, err
+	}
+	if claims.TokenType != AccessToken && claims.TokenType != RefreshToken {
+		return nil, ErrInvalidTokenType
+	}
+	return claims, nil
+}
+
+// checkFamily 启用刷新令牌轮换时检查令牌是否仍为所属家族的当前令牌，未配置 FamilyStore 或令牌不属于任何家族时直接返回 nil。
+// 查询家族状态失败时视为验证失败。
+func (m *Manager) checkFamily(ctx context.Context, claims *Claims) error {
+	store := m.opts.FamilyStore
+	if store == nil || claims.FamilyID == "" {
+		return nil
+	}
+	current, err := store.Current(ctx, claims.FamilyID)
+	if err != nil {
+		return fmt.Errorf("jwt: check token family: %w", err)
+	}
+	if current == "" || current != claims.ID {
+		return ErrTokenRevoked
+	}
+	return nil
+}
+
+// checkRevoked 检查令牌是否已按 jti 或 subject 被撤销，未配置 Revoker 时直接返回 nil。
+// 查询撤销状态失败时视为验证失败，避免存储故障期间放行已撤销的令牌。
+func (m *Manager) checkRevoked(ctx context.Context, claims *Claims) error {
+	revoker := m.opts.Revoker
+	if revoker == nil {
+		return nil
+	}
+
+	if claims.ID != "" {
+		revoked, err := revoker.IsRevoked(ctx, claims.ID)
+		if err != nil {
+			return fmt.Errorf("jwt: check revocation: %w", err)
+		}
+		if revoked {
+			return ErrTokenRevoked
+		}
+	}
+
+	revokedAt, err := revoker.SubjectRevokedAt(ctx, claims.Subject)
+	if err != nil {
+		return fmt.Errorf("jwt: check revocation: %w", err)
+	}
+	if !revokedAt.IsZero() && (claims.IssuedAt == nil || claims.IssuedAt.Unix() < revokedAt.Unix()) {
+		return ErrTokenRevoked
+	}
+	return nil
+}
@@ -2,7 +2,10 @@ package jwt
 
 import (
 	"context"
+	"crypto"
 	"time"
+
+	"github.com/3086953492/gokit/security/subject"
 )
 
 // 默认配置值。
@@ -13,6 +16,9 @@ const (
 	// DefaultRefreshTTL 默认刷新令牌有效期：7 天。
 	DefaultRefreshTTL = 7 * 24 * time.Hour
 
+	// DefaultIDTokenTTL 默认 ID 令牌有效期：1 小时。
+	DefaultIDTokenTTL = time.Hour
+
 	// DefaultIssuer 默认签发者。
 	DefaultIssuer = "gokit"
 )
@@ -26,18 +32,76 @@ type Options struct {
 	// 建议与 AccessSecret 使用不同的密钥以增强安全性。
 	RefreshSecret string
 
+	// AccessKeySet 访问令牌密钥集，可选，支持 kid 选择与运行时轮换。
+	// 配置后优先于 AccessPrivateKey、AccessPublicKey 与 AccessSecret。
+	AccessKeySet *KeySet
+
+	// RefreshKeySet 刷新令牌密钥集，可选，配置后优先于 RefreshSecret。
+	RefreshKeySet *KeySet
+
+	// AccessPrivateKey 访问令牌的非对称签名私钥（RSA、ECDSA 或 Ed25519），可选。
+	// 配置后访问令牌按密钥类型使用 RS256、ES256/ES384/ES512 或 EdDSA 签名，
+	// 优先于 AccessSecret；其他服务只需持有公钥即可验证访问令牌。
+	AccessPrivateKey crypto.Signer
+
+	// AccessPublicKey 访问令牌的验签公钥，可选。
+	// 仅配置公钥时 Manager 只能解析访问令牌，适用于不持有私钥的下游服务。
+	// 同时配置 AccessPrivateKey 时以私钥导出的公钥为准。
+	AccessPublicKey crypto.PublicKey
+
+	// AllowedAlgorithms 解析访问令牌时接受的签名算法白名单，如 []string{"RS256"}。
+	// 为空时仅接受所配置密钥自身的算法；不为空时所配置密钥的算法必须包含在内。
+	// 刷新令牌只由签发方自己验证，不受此项影响。
+	AllowedAlgorithms []string
+
 	// Issuer 令牌签发者。
 	Issuer string
 
+	// VerifyIssuer 解析时是否要求 iss 与 Issuer 一致，默认 true。
+	// 防止共享密钥的其他签发方签发的令牌被接受。
+	VerifyIssuer bool
+
+	// Audience 令牌受众，可选。
+	// 配置后签发的令牌写入 aud，解析时要求 aud 至少包含其中之一。
+	Audience []string
+
+	// Leeway 校验 exp、nbf、iat 时允许的时钟偏差，默认 0。
+	Leeway time.Duration
+
+	// RequiredClaims 解析时必须存在的声明，如 "jti"、"aud"；非注册声明名称在 Extra 中查找。
+	RequiredClaims []string
+
+	// Validator 自定义校验函数，可选，在签名与注册声明校验通过后调用。
+	// 返回的错误会包装为 ErrInvalidToken。
+	Validator func(claims *Claims) error
+
 	// AccessTTL 访问令牌有效期。
 	AccessTTL time.Duration
 
 	// RefreshTTL 刷新令牌有效期。
 	RefreshTTL time.Duration
 
+	// IDTokenTTL OIDC ID 令牌有效期。
+	IDTokenTTL time.Duration
+
+	// SubjectManager 生成 ID 令牌 sub 的 subject 管理器，签发 ID 令牌时必填。
+	SubjectManager *subject.Manager
+
 	// Resolver 刷新时用于加载用户信息的回调，可选。
 	// 若未配置，调用 RefreshAccessToken 将返回 ErrResolverNotConfigured。
 	Resolver ExtraResolver
+
+	// Revoker 令牌撤销存储，可选。
+	// 配置后解析令牌时会检查 jti 与 subject 是否已被撤销。
+	Revoker Revoker
+
+	// FamilyStore 刷新令牌家族存储，可选。
+	// 配置后启用刷新令牌轮换：每次 RefreshTokenPair 都会签发同一家族的新刷新令牌并使旧令牌失效，
+	// 旧令牌被再次使用时整个家族随之撤销。
+	FamilyStore FamilyStore
+
+	// err 记录加载 PEM 密钥时的错误，由 NewManager 返回。
+	err error
 }
 
 // Option 是配置 Manager 的函数类型。
@@ -46,9 +110,11 @@ type Option func(*Options)
 // defaultOptions 返回带有默认值的 Options。
 func defaultOptions() *Options {
 	return &Options{
-		Issuer:     DefaultIssuer,
-		AccessTTL:  DefaultAccessTTL,
-		RefreshTTL: DefaultRefreshTTL,
+		Issuer:       DefaultIssuer,
+		VerifyIssuer: true,
+		AccessTTL:    DefaultAccessTTL,
+		RefreshTTL:   DefaultRefreshTTL,
+		IDTokenTTL:   DefaultIDTokenTTL,
 	}
 }
 
@@ -75,6 +141,91 @@ func WithSecret(secret string) Option {
 	}
 }
 
+// WithAccessKeySet 设置访问令牌密钥集。
+func WithAccessKeySet(set *KeySet) Option {
+	return func(o *Options) {
+		o.AccessKeySet = set
+	}
+}
+
+// WithRefreshKeySet 设置刷新令牌密钥集。
+func WithRefreshKeySet(set *KeySet) Option {
+	return func(o *Options) {
+		o.RefreshKeySet = set
+	}
+}
+
+// WithAccessPrivateKey 设置访问令牌的非对称签名私钥。
+func WithAccessPrivateKey(key crypto.Signer) Option {
+	return func(o *Options) {
+		o.AccessPrivateKey = key
+	}
+}
+
+// WithAccessPrivateKeyPEM 从 PEM 数据加载访问令牌签名私钥。
+// 支持 PKCS#8、PKCS#1（RSA）与 SEC 1（EC）格式，解析失败时由 NewManager 返回错误。
+func WithAccessPrivateKeyPEM(data []byte) Option {
+	return func(o *Options) {
+		key, err := decodePrivateKeyPEM(data)
+		if err != nil {
+			o.err = err
+			return
+		}
+		o.AccessPrivateKey = key
+	}
+}
+
+// WithAccessPrivateKeyFile 从 PEM 文件加载访问令牌签名私钥。
+func WithAccessPrivateKeyFile(path string) Option {
+	return func(o *Options) {
+		data, err := readKeyFile(path)
+		if err != nil {
+			o.err = err
+			return
+		}
+		WithAccessPrivateKeyPEM(data)(o)
+	}
+}
+
+// WithAccessPublicKey 设置访问令牌的验签公钥。
+func WithAccessPublicKey(key crypto.PublicKey) Option {
+	return func(o *Options) {
+		o.AccessPublicKey = key
+	}
+}
+
+// WithAccessPublicKeyPEM 从 PEM 数据加载访问令牌验签公钥。
+// 支持 PKIX、PKCS#1（RSA）公钥与 X.509 证书，解析失败时由 NewManager 返回错误。
+func WithAccessPublicKeyPEM(data []byte) Option {
+	return func(o *Options) {
+		key, err := decodePublicKeyPEM(data)
+		if err != nil {
+			o.err = err
+			return
+		}
+		o.AccessPublicKey = key
+	}
+}
+
+// WithAccessPublicKeyFile 从 PEM 文件加载访问令牌验签公钥。
+func WithAccessPublicKeyFile(path string) Option {
+	return func(o *Options) {
+		data, err := readKeyFile(path)
+		if err != nil {
+			o.err = err
+			return
+		}
+		WithAccessPublicKeyPEM(data)(o)
+	}
+}
+
+// WithAllowedAlgorithms 设置解析访问令牌时接受的签名算法白名单。
+func WithAllowedAlgorithms(algs ...string) Option {
+	return func(o *Options) {
+		o.AllowedAlgorithms = algs
+	}
+}
+
 // WithIssuer 设置令牌签发者。
 func WithIssuer(issuer string) Option {
 	return func(o *Options) {
@@ -82,6 +233,41 @@ func WithIssuer(issuer string) Option {
 	}
 }
 
+// WithIssuerVerification 设置解析时是否校验签发者。
+func WithIssuerVerification(enabled bool) Option {
+	return func(o *Options) {
+		o.VerifyIssuer = enabled
+	}
+}
+
+// WithAudience 设置令牌受众，可传入多个。
+func WithAudience(audience ...string) Option {
+	return func(o *Options) {
+		o.Audience = audience
+	}
+}
+
+// WithLeeway 设置时间类声明校验允许的时钟偏差。
+func WithLeeway(leeway time.Duration) Option {
+	return func(o *Options) {
+		o.Leeway = leeway
+	}
+}
+
+// WithRequiredClaims 设置解析时必须存在的声明。
+func WithRequiredClaims(names ...string) Option {
+	return func(o *Options) {
+		o.RequiredClaims = names
+	}
+}
+
+// WithValidator 设置自定义校验函数。
+func WithValidator(fn func(claims *Claims) error) Option {
+	return func(o *Options) {
+		o.Validator = fn
+	}
+}
+
 // WithAccessTTL 设置访问令牌有效期。
 func WithAccessTTL(ttl time.Duration) Option {
 	return func(o *Options) {
@@ -96,6 +282,20 @@ func WithRefreshTTL(ttl time.Duration) Option {
 	}
 }
 
+// WithIDTokenTTL 设置 ID 令牌有效期。
+func WithIDTokenTTL(ttl time.Duration) Option {
+	return func(o *Options) {
+		o.IDTokenTTL = ttl
+	}
+}
+
+// WithSubjectManager 设置生成 ID 令牌 sub 的 subject 管理器。
+func WithSubjectManager(mgr *subject.Manager) Option {
+	return func(o *Options) {
+		o.SubjectManager = mgr
+	}
+}
+
 // WithExtraResolver 设置刷新时加载用户信息的回调。
 func WithExtraResolver(resolver ExtraResolver) Option {
 	return func(o *Options) {
@@ -103,6 +303,20 @@ func WithExtraResolver(resolver ExtraResolver) Option {
 	}
 }
 
+// WithRevoker 设置令牌撤销存储。
+func WithRevoker(revoker Revoker) Option {
+	return func(o *Options) {
+		o.Revoker = revoker
+	}
+}
+
+// WithRefreshRotation 启用刷新令牌轮换并设置家族存储。
+func WithRefreshRotation(store FamilyStore) Option {
+	return func(o *Options) {
+		o.FamilyStore = store
+	}
+}
+
 // WithExtraResolverFunc 使用函数作为 ExtraResolver。
 func WithExtraResolverFunc(fn func(ctx context.Context, subject string) (map[string]any, error)) Option {
 	return func(o *Options) {
@@ -0,0 +1,234 @@
+package jwt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// 远程 JWKS 默认配置值。
+const (
+	// DefaultJWKSCacheTTL 默认 JWKS 缓存有效期：10 分钟。
+	DefaultJWKSCacheTTL = 10 * time.Minute
+
+	// DefaultJWKSMinRefreshInterval 默认两次拉取 JWKS 的最小间隔：30 秒。
+	DefaultJWKSMinRefreshInterval = 30 * time.Second
+
+	// maxJWKSSize JWKS 响应体大小上限
+	maxJWKSSize = 1 << 20
+)
+
+// RemoteOption 是配置 RemoteVerifier 的函数类型。
+type RemoteOption func(*remoteOptions)
+
+type remoteOptions struct {
+	client             *http.Client
+	cacheTTL           time.Duration
+	minRefreshInterval time.Duration
+	rules              validation
+}
+
+// WithJWKSHTTPClient 设置拉取 JWKS 使用的 HTTP 客户端，默认使用 10 秒超时的客户端。
+func WithJWKSHTTPClient(client *http.Client) RemoteOption {
+	return func(o *remoteOptions) {
+		if client != nil {
+			o.client = client
+		}
+	}
+}
+
+// WithJWKSCacheTTL 设置 JWKS 缓存有效期，过期后下一次验证时重新拉取。
+func WithJWKSCacheTTL(ttl time.Duration) RemoteOption {
+	return func(o *remoteOptions) {
+		if ttl > 0 {
+			o.cacheTTL = ttl
+		}
+	}
+}
+
+// WithJWKSMinRefreshInterval 设置两次拉取 JWKS 的最小间隔。
+// 遇到未知 kid 时会立即重新拉取，此间隔防止伪造 kid 的请求打满 JWKS 端点。
+func WithJWKSMinRefreshInterval(d time.Duration) RemoteOption {
+	return func(o *remoteOptions) {
+		if d >= 0 {
+			o.minRefreshInterval = d
+		}
+	}
+}
+
+// WithJWKSAllowedAlgorithms 设置接受的签名算法白名单，为空时接受与 JWK 类型匹配的算法。
+func WithJWKSAllowedAlgorithms(algs ...string) RemoteOption {
+	return func(o *remoteOptions) {
+		o.rules.allowed = algs
+	}
+}
+
+// WithJWKSIssuer 设置要求的签发者，令牌 iss 必须与之一致。
+func WithJWKSIssuer(issuer string) RemoteOption {
+	return func(o *remoteOptions) {
+		o.rules.issuer = issuer
+	}
+}
+
+// WithJWKSAudience 设置接受的受众，令牌 aud 必须至少包含其中之一。
+func WithJWKSAudience(audience ...string) RemoteOption {
+	return func(o *remoteOptions) {
+		o.rules.audience = audience
+	}
+}
+
+// WithJWKSLeeway 设置时间类声明校验允许的时钟偏差。
+func WithJWKSLeeway(leeway time.Duration) RemoteOption {
+	return func(o *remoteOptions) {
+		o.rules.leeway = leeway
+	}
+}
+
+// RemoteVerifier 通过远程 JWKS 端点获取公钥并验证访问令牌，适用于不持有签名密钥的下游服务。
+// JWKS 会按 TTL 缓存；遇到未知 kid 时立即重新拉取（受最小间隔限制），以便及时识别签发方轮换的新密钥。
+// 拉取失败时继续使用已缓存的密钥。RemoteVerifier 是线程安全的。
+//
+//	verifier := jwt.NewRemoteVerifier("https://auth.example.com/.well-known/jwks.json")
+//	claims, err := verifier.Verify(ctx, tokenString)
+type RemoteVerifier struct {
+	url  string
+	opts remoteOptions
+
+	// fetchMu 串行化拉取过程
+	fetchMu sync.Mutex
+
+	mu        sync.RWMutex
+	keys      *KeySet
+	fetchedAt time.Time
+	lastFetch time.Time
+	lastErr   error
+}
+
+// NewRemoteVerifier 创建远程 JWKS 验证器，首次验证时才会拉取 JWKS。
+func NewRemoteVerifier(jwksURL string, opts ...RemoteOption) *RemoteVerifier {
+	o := remoteOptions{
+		client:             &http.Client{Timeout: 10 * time.Second},
+		cacheTTL:           DefaultJWKSCacheTTL,
+		minRefreshInterval: DefaultJWKSMinRefreshInterval,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &RemoteVerifier{url: jwksURL, opts: o}
+}
+
+// Verify 验证访问令牌并返回 Claims，令牌类型不是 access 时返回 ErrInvalidTokenType。
+func (v *RemoteVerifier) Verify(ctx context.Context, tokenString string) (*Claims, error) {
+	claims, err := parseTokenWith(tokenString, func(kid string) (*Key, error) {
+		return v.lookup(ctx, kid)
+	}, &v.opts.rules)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.TokenType != AccessToken {
+		return nil, ErrInvalidTokenType
+	}
+	return claims, nil
+}
+
+// Refresh 立即重新拉取 JWKS，不受最小间隔限制。
+func (v *RemoteVerifier) Refresh(ctx context.Context) error {
+	_, err := v.refresh(ctx, true)
+	return err
+}
+
+// lookup 按 kid 查找公钥，缓存过期或 kid 未知时重新拉取
+func (v *RemoteVerifier) lookup(ctx context.Context, kid string) (*Key, error) {
+	v.mu.RLock()
+	keys, fetchedAt := v.keys, v.fetchedAt
+	v.mu.RUnlock()
+
+	if keys != nil && time.Since(fetchedAt) < v.opts.cacheTTL {
+		if k, ok := keys.Lookup(kid); ok {
+			return k, nil
+		}
+	}
+
+	keys, err := v.refresh(ctx, false)
+	if keys == nil {
+		return nil, err
+	}
+	if k, ok := keys.Lookup(kid); ok {
+		return k, nil
+	}
+	return nil, fmt.Errorf("%w: kid %q", ErrKeyNotFound, kid)
+}
+
+// refresh 拉取 JWKS 并更新缓存，返回当前可用的密钥集。
+// 非强制刷新时，距上次拉取不足最小间隔则直接返回缓存；拉取失败时返回旧缓存与错误。
+func (v *RemoteVerifier) refresh(ctx context.Context, force bool) (*KeySet, error) {
+	v.fetchMu.Lock()
+	defer v.fetchMu.Unlock()
+
+	v.mu.RLock()
+	keys, lastFetch, lastErr := v.keys, v.lastFetch, v.lastErr
+	v.mu.RUnlock()
+	if !force && !lastFetch.IsZero() && time.Since(lastFetch) < v.opts.minRefreshInterval {
+		return keys, lastErr
+	}
+
+	fetched, err := v.fetch(ctx)
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.lastFetch = time.Now()
+	v.lastErr = err
+	if err != nil {
+		return v.keys, err
+	}
+	v.keys = fetched
+	v.fetchedAt = v.lastFetch
+	return fetched, nil
+}
+
+// fetch 请求 JWKS 端点并解析为仅验签的密钥集，跳过不支持或非签名用途的密钥
+func (v *RemoteVerifier) fetch(ctx context.Context) (*KeySet, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrJWKSFetch, err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := v.opts.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrJWKSFetch, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: unexpected status %d", ErrJWKSFetch, resp.StatusCode)
+	}
+
+	var doc JWKS
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxJWKSSize)).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: decode: %v", ErrJWKSFetch, err)
+	}
+
+	keys := make([]*Key, 0, len(doc.Keys))
+	seen := make(map[string]bool, len(doc.Keys))
+	for _, jwk := range doc.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.Key()
+		if err != nil || seen[key.ID()] {
+			continue
+		}
+		seen[key.ID()] = true
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: no usable keys", ErrJWKSFetch)
+	}
+	return NewKeySet(nil, keys...)
+}
@@ -0,0 +1,152 @@
+package jwt
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/3086953492/gokit/redis"
+)
+
+// Revoker 定义令牌撤销存储接口。
+// 单个令牌按 jti 撤销；按 subject 撤销时记录撤销时间，此前签发的令牌全部失效。
+type Revoker interface {
+	// Revoke 撤销指定 jti，记录只需保留到令牌自然过期的 expiresAt。
+	Revoke(ctx context.Context, jti string, expiresAt time.Time) error
+
+	// IsRevoked 返回 jti 是否已被撤销。
+	IsRevoked(ctx context.Context, jti string) (bool, error)
+
+	// RevokeSubject 记录 subject 的撤销时间 at，记录保留 ttl（应不小于最长令牌有效期）。
+	RevokeSubject(ctx context.Context, subject string, at time.Time, ttl time.Duration) error
+
+	// SubjectRevokedAt 返回 subject 最近一次撤销时间，无记录时返回零值。
+	SubjectRevokedAt(ctx context.Context, subject string) (time.Time, error)
+}
+
+var (
+	_ Revoker = (*RedisRevoker)(nil)
+	_ Revoker = (*MemoryRevoker)(nil)
+)
+
+// RedisRevoker 基于 redis.Manager 的令牌撤销存储，适用于多实例部署。
+// 记录随令牌过期自动清理，key 形如 "jwt:revoked:jti:<jti>"、"jwt:revoked:sub:<subject>"。
+type RedisRevoker struct {
+	mgr    *redis.Manager
+	prefix string
+}
+
+// NewRedisRevoker 创建 Redis 令牌撤销存储。
+func NewRedisRevoker(mgr *redis.Manager) *RedisRevoker {
+	return &RedisRevoker{mgr: mgr, prefix: "jwt:revoked:"}
+}
+
+// Revoke 实现 Revoker 接口。
+func (r *RedisRevoker) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	return r.mgr.SetBytes(ctx, r.prefix+"jti:"+jti, []byte{'1'}, ttl)
+}
+
+// IsRevoked 实现 Revoker 接口。
+func (r *RedisRevoker) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	return r.mgr.Exists(ctx, r.prefix+"jti:"+jti)
+}
+
+// RevokeSubject 实现 Revoker 接口。
+func (r *RedisRevoker) RevokeSubject(ctx context.Context, subject string, at time.Time, ttl time.Duration) error {
+	return r.mgr.SetBytes(ctx, r.prefix+"sub:"+subject, strconv.AppendInt(nil, at.Unix(), 10), ttl)
+}
+
+// SubjectRevokedAt 实现 Revoker 接口。
+func (r *RedisRevoker) SubjectRevokedAt(ctx context.Context, subject string) (time.Time, error) {
+	data, err := r.mgr.GetBytes(ctx, r.prefix+"sub:"+subject)
+	if err != nil || data == nil {
+		return time.Time{}, err
+	}
+	sec, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(sec, 0), nil
+}
+
+// MemoryRevoker 进程内令牌撤销存储，适用于单实例部署与测试。
+// 使用惰性过期策略，写入时顺带清理过期记录。
+type MemoryRevoker struct {
+	mu       sync.Mutex
+	tokens   map[string]time.Time
+	subjects map[string]subjectRevocation
+}
+
+type subjectRevocation struct {
+	at       time.Time
+	expireAt time.Time
+}
+
+// NewMemoryRevoker 创建内存令牌撤销存储。
+func NewMemoryRevoker() *MemoryRevoker {
+	return &MemoryRevoker{
+		tokens:   make(map[string]time.Time),
+		subjects: make(map[string]subjectRevocation),
+	}
+}
+
+// Revoke 实现 Revoker 接口。
+func (r *MemoryRevoker) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.prune()
+	r.tokens[jti] = expiresAt
+	return nil
+}
+
+// IsRevoked 实现 Revoker 接口。
+func (r *MemoryRevoker) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	expireAt, ok := r.tokens[jti]
+	return ok && time.Now().Before(expireAt), nil
+}
+
+// RevokeSubject 实现 Revoker 接口。
+func (r *MemoryRevoker) RevokeSubject(ctx context.Context, subject string, at time.Time, ttl time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.prune()
+	r.subjects[subject] = subjectRevocation{at: at, expireAt: time.Now().Add(ttl)}
+	return nil
+}
+
+// SubjectRevokedAt 实现 Revoker 接口。
+func (r *MemoryRevoker) SubjectRevokedAt(ctx context.Context, subject string) (time.Time, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rev, ok := r.subjects[subject]
+	if !ok || time.Now().After(rev.expireAt) {
+		return time.Time{}, nil
+	}
+	return rev.at, nil
+}
+
+// prune 清理过期记录，调用方需持有锁
+func (r *MemoryRevoker) prune() {
+	now := time.Now()
+	for jti, expireAt := range r.tokens {
+		if now.After(expireAt) {
+			delete(r.tokens, jti)
+		}
+	}
+	for sub, rev := range r.subjects {
+		if now.After(rev.expireAt) {
+			delete(r.subjects, sub)
+		}
+	}
+}
@@ -6,23 +6,25 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
-// generateToken 生成指定类型的 JWT 令牌。
-func generateToken(
-	secret string,
+// newClaims 构造指定类型令牌的声明，jti 随机生成。
+func newClaims(
 	issuer string,
+	audience []string,
 	ttl time.Duration,
 	tokenType TokenType,
 	subject string,
 	extra map[string]any,
-) (string, error) {
+) *Claims {
 	now := time.Now()
 
-	claims := Claims{
+	claims := &Claims{
 		TokenType: tokenType,
 		Extra:     extra,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
 			Subject:   subject,
 			Issuer:    issuer,
 			IssuedAt:  jwt.NewNumericDate(now),
@@ -30,20 +32,118 @@ func generateToken(
 			NotBefore: jwt.NewNumericDate(now),
 		},
 	}
+	if len(audience) > 0 {
+		claims.Audience = jwt.ClaimStrings(audience)
+	}
+	return claims
+}
+
+// signToken 使用密钥签名声明，kid 不为空时写入 header。
+func signToken(key *Key, claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(key.method, claims)
+	if key.id != "" {
+		token.Header["kid"] = key.id
+	}
+	return token.SignedString(key.signKey)
+}
+
+// validation 解析令牌时的校验规则。
+type validation struct {
+	// allowed 签名算法白名单，为空时仅要求算法与密钥一致
+	allowed []string
+
+	// issuer 不为空时要求 iss 与之相等
+	issuer string
+
+	// audience 不为空时要求 aud 至少包含其中之一
+	audience []string
+
+	// leeway 校验 exp、nbf、iat 时允许的时钟偏差
+	leeway time.Duration
+
+	// required 必须存在的声明名称
+	required []string
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(secret))
+	// validate 自定义校验
+	validate func(claims *Claims) error
+}
+
+// parserOptions 返回对应的 golang-jwt 解析选项
+func (v *validation) parserOptions() []jwt.ParserOption {
+	opts := []jwt.ParserOption{jwt.WithLeeway(v.leeway), jwt.WithIssuedAt()}
+	if len(v.allowed) > 0 {
+		opts = append(opts, jwt.WithValidMethods(v.allowed))
+	}
+	if v.issuer != "" {
+		opts = append(opts, jwt.WithIssuer(v.issuer))
+	}
+	if len(v.audience) > 0 {
+		opts = append(opts, jwt.WithAudience(v.audience...))
+	}
+	return opts
+}
+
+// check 校验必需声明并执行自定义校验
+func (v *validation) check(claims *Claims) error {
+	for _, name := range v.required {
+		if !hasClaim(claims, name) {
+			return fmt.Errorf("%w: missing required claim %q", ErrInvalidToken, name)
+		}
+	}
+	if v.validate != nil {
+		if err := v.validate(claims); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidToken, err)
+		}
+	}
+	return nil
+}
+
+// hasClaim 判断声明是否存在，非注册声明名称在 Extra 中查找
+func hasClaim(c *Claims, name string) bool {
+	switch name {
+	case "iss":
+		return c.Issuer != ""
+	case "sub":
+		return c.Subject != ""
+	case "aud":
+		return len(c.Audience) > 0
+	case "exp":
+		return c.ExpiresAt != nil
+	case "nbf":
+		return c.NotBefore != nil
+	case "iat":
+		return c.IssuedAt != nil
+	case "jti":
+		return c.ID != ""
+	case "token_type":
+		return c.TokenType != ""
+	case "fid":
+		return c.FamilyID != ""
+	case "client_id":
+		return c.ClientID != ""
+	case "scope":
+		return c.Scope != ""
+	}
+	_, ok := c.Extra[name]
+	return ok
 }
 
 // parseToken 解析并验证 JWT 令牌，返回 Claims。
-func parseToken(tokenString, secret string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (any, error) {
-		// 验证签名方法
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("jwt: unexpected signing method: %v", token.Header["alg"])
+// 按 header 中的 kid 从密钥集选择验签密钥，签名算法必须与该密钥一致，
+// 防止以公钥作为 HMAC 密钥的算法混淆攻击；白名单不为空时还需在白名单内。
+func parseToken(tokenString string, keys *KeySet, rules *validation) (*Claims, error) {
+	return parseTokenWith(tokenString, func(kid string) (*Key, error) {
+		key, ok := keys.Lookup(kid)
+		if !ok {
+			return nil, fmt.Errorf("%w: kid %q", ErrKeyNotFound, kid)
 		}
-		return []byte(secret), nil
-	})
+		return key, nil
+	}, rules)
+}
+
+// parseTokenWith 使用 lookup 按 kid 获取验签密钥并解析令牌。
+func parseTokenWith(tokenString string, lookup func(kid string) (*Key, error), rules *validation) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, keyFunc(lookup), rules.parserOptions()...)
 
 	if err != nil {
 		// 判断是否为过期错误
@@ -58,5 +158,25 @@ func parseToken(tokenString, secret string) (*Claims, error) {
 		return nil, ErrInvalidToken
 	}
 
+	if err := rules.check(claims); err != nil {
+		return nil, err
+	}
+
 	return claims, nil
 }
+
+// keyFunc 返回按 kid 选择验签密钥的 jwt.Keyfunc，要求令牌算法与密钥算法一致
+func keyFunc(lookup func(kid string) (*Key, error)) jwt.Keyfunc {
+	return func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, err := lookup(kid)
+		if err != nil {
+			return nil, err
+		}
+		// 验证签名方法
+		if token.Method.Alg() != key.Algorithm() {
+			return nil, fmt.Errorf("jwt: unexpected signing method: %v", token.Header["alg"])
+		}
+		return key.verifyKey, nil
+	}
+}
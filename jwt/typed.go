@@ -0,0 +1,136 @@
+package jwt
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// TypedClaims 是 Claims 的强类型版本，Extra 解码为调用方定义的类型 T。
+type TypedClaims[T any] struct {
+	// TokenType 令牌类型：access 或 refresh。
+	TokenType TokenType `json:"token_type"`
+
+	// Extra 强类型扩展字段。
+	Extra T `json:"extra"`
+
+	// FamilyID 刷新令牌所属家族。
+	FamilyID string `json:"fid,omitempty"`
+
+	// ClientID 获得授权的 OAuth 2.0 客户端。
+	ClientID string `json:"client_id,omitempty"`
+
+	// Scope 以空格分隔的授权范围。
+	Scope string `json:"scope,omitempty"`
+
+	jwt.RegisteredClaims
+}
+
+// TypedExtraResolver 是 ExtraResolver 的强类型版本。
+type TypedExtraResolver[T any] interface {
+	// ResolveExtra 根据 subject 加载扩展信息。
+	ResolveExtra(ctx context.Context, subject string) (T, error)
+}
+
+// TypedExtraResolverFunc 是 TypedExtraResolver 的函数适配器。
+type TypedExtraResolverFunc[T any] func(ctx context.Context, subject string) (T, error)
+
+// ResolveExtra 实现 TypedExtraResolver 接口。
+func (f TypedExtraResolverFunc[T]) ResolveExtra(ctx context.Context, subject string) (T, error) {
+	return f(ctx, subject)
+}
+
+// ResolverOf 将 TypedExtraResolver 适配为 ExtraResolver，可传给 SetExtraResolver。
+//
+//	jwtMgr.SetExtraResolver(jwt.ResolverOf[UserExtra](userService))
+func ResolverOf[T any](r TypedExtraResolver[T]) ExtraResolver {
+	return ExtraResolverFunc(func(ctx context.Context, subject string) (map[string]any, error) {
+		extra, err := r.ResolveExtra(ctx, subject)
+		if err != nil {
+			return nil, err
+		}
+		return toExtraMap(extra)
+	})
+}
+
+// WithTypedExtraResolver 使用 TypedExtraResolver 作为刷新时加载用户信息的回调。
+func WithTypedExtraResolver[T any](r TypedExtraResolver[T]) Option {
+	return WithExtraResolver(ResolverOf(r))
+}
+
+// GenerateAccessTokenFor 生成访问令牌，extra 以 JSON 编码后写入 extra 声明。
+// T 必须编码为 JSON 对象（结构体或 map）。
+//
+//	token, err := jwt.GenerateAccessTokenFor(jwtMgr, userID, UserExtra{Roles: roles, TenantID: 7})
+func GenerateAccessTokenFor[T any](m *Manager, subject string, extra T) (string, error) {
+	data, err := toExtraMap(extra)
+	if err != nil {
+		return "", err
+	}
+	return m.GenerateAccessToken(subject, data)
+}
+
+// GenerateTokenPairFor 同时生成访问令牌和刷新令牌，extra 仅写入访问令牌。
+func GenerateTokenPairFor[T any](ctx context.Context, m *Manager, subject string, extra T) (string, string, error) {
+	data, err := toExtraMap(extra)
+	if err != nil {
+		return "", "", err
+	}
+	return m.GenerateTokenPairContext(ctx, subject, data)
+}
+
+// ParseAccessTokenAs 解析访问令牌，并将 extra 声明解码为 T。
+// 校验规则与 ParseAccessToken 完全一致。
+func ParseAccessTokenAs[T any](m *Manager, tokenString string) (*TypedClaims[T], error) {
+	return ParseAccessTokenAsContext[T](context.Background(), m, tokenString)
+}
+
+// ParseAccessTokenAsContext 与 ParseAccessTokenAs 相同，ctx 用于查询撤销状态。
+func ParseAccessTokenAsContext[T any](ctx context.Context, m *Manager, tokenString string) (*TypedClaims[T], error) {
+	if _, err := m.ParseAccessTokenContext(ctx, tokenString); err != nil {
+		return nil, err
+	}
+	return decodeTyped[T](tokenString)
+}
+
+// decodeTyped 将已验证令牌的载荷直接解码为 TypedClaims，避免经由 map 转换丢失数值精度
+func decodeTyped[T any](tokenString string) (*TypedClaims[T], error) {
+	parts := strings.Split(tokenString, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	var claims TypedClaims[T]
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("%w: decode extra: %v", ErrInvalidToken, err)
+	}
+	return &claims, nil
+}
+
+// toExtraMap 将 extra 编码为 map，数值以 json.Number 保存以保证重新编码时不丢失精度
+func toExtraMap[T any](extra T) (map[string]any, error) {
+	data, err := json.Marshal(extra)
+	if err != nil {
+		return nil, fmt.Errorf("jwt: encode extra: %w", err)
+	}
+	if bytes.Equal(data, []byte("null")) {
+		return nil, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var m map[string]any
+	if err := dec.Decode(&m); err != nil {
+		return nil, fmt.Errorf("jwt: extra must encode as a JSON object: %w", err)
+	}
+	return m, nil
+}
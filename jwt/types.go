@@ -26,6 +26,15 @@ type Claims struct {
 	// Extra 自定义扩展字段，可存放角色、权限等信息。
 	Extra map[string]any `json:"extra,omitempty"`
 
+	// FamilyID 刷新令牌所属家族，仅在启用刷新令牌轮换时写入刷新令牌。
+	FamilyID string `json:"fid,omitempty"`
+
+	// ClientID 获得授权的 OAuth 2.0 客户端（RFC 9068），仅 OAuth 授权签发的令牌携带。
+	ClientID string `json:"client_id,omitempty"`
+
+	// Scope 以空格分隔的授权范围（RFC 9068），仅 OAuth 授权签发的令牌携带。
+	Scope string `json:"scope,omitempty"`
+
 	jwt.RegisteredClaims
 }
 
@@ -0,0 +1,192 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/3086953492/gokit/jwt"
+	"github.com/3086953492/gokit/security/random"
+)
+
+// 随机值长度：state 与 nonce 约 256 位熵，PKCE verifier 取 RFC 7636 允许的较长值
+const (
+	stateLength    = 43
+	verifierLength = 64
+
+	// maxTokenResponseSize 令牌响应体大小上限
+	maxTokenResponseSize = 1 << 20
+)
+
+// Client OAuth 2.0 / OIDC 客户端，线程安全
+type Client struct {
+	opts *Options
+
+	// verifier 申请了 openid 时用于验证 ID 令牌，否则为 nil
+	verifier *jwt.IDTokenVerifier
+}
+
+// NewClient 创建客户端
+func NewClient(opts ...Option) (*Client, error) {
+	o := defaultOptions()
+	for _, fn := range opts {
+		fn(o)
+	}
+
+	if o.ClientID == "" {
+		return nil, ErrMissingClientID
+	}
+	if o.AuthURL == "" || o.TokenURL == "" || o.RedirectURI == "" {
+		return nil, ErrMissingEndpoint
+	}
+	if len(o.CookieSecret) == 0 {
+		return nil, ErrMissingCookieSecret
+	}
+
+	cl := &Client{opts: o}
+	if slices.Contains(o.Scopes, "openid") {
+		cl.verifier = o.IDTokenVerifier
+		if cl.verifier == nil && o.JWKSURL != "" && o.Issuer != "" {
+			keys := jwt.NewRemoteVerifier(o.JWKSURL, jwt.WithJWKSHTTPClient(o.HTTPClient))
+			cl.verifier = jwt.NewIDTokenVerifier(keys, o.Issuer, o.ClientID)
+		}
+		if cl.verifier == nil {
+			return nil, ErrMissingVerifier
+		}
+	}
+	return cl, nil
+}
+
+// AuthCodeURL 生成授权地址，并将 state、nonce 与 PKCE verifier 写入签名 Cookie。
+// next 为登录完成后的站内跳转路径，非站内路径会被忽略。
+// 适用于需要以 JSON 返回授权地址的前后端分离场景；服务端渲染场景直接使用 LoginHandler。
+func (cl *Client) AuthCodeURL(c *gin.Context, next string) (string, error) {
+	st := &loginState{
+		Next:    safeNext(next),
+		Expires: time.Now().Add(cl.opts.LoginTimeout).Unix(),
+	}
+	var err error
+	if st.State, err = random.URLSafe(stateLength); err != nil {
+		return "", err
+	}
+	if st.Verifier, err = random.URLSafe(verifierLength); err != nil {
+		return "", err
+	}
+	if cl.verifier != nil {
+		if st.Nonce, err = random.URLSafe(stateLength); err != nil {
+			return "", err
+		}
+	}
+
+	u, err := url.Parse(cl.opts.AuthURL)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrMissingEndpoint, err)
+	}
+	sum := sha256.Sum256([]byte(st.Verifier))
+	query := u.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", cl.opts.ClientID)
+	query.Set("redirect_uri", cl.opts.RedirectURI)
+	query.Set("state", st.State)
+	query.Set("code_challenge", base64.RawURLEncoding.EncodeToString(sum[:]))
+	query.Set("code_challenge_method", "S256")
+	if len(cl.opts.Scopes) > 0 {
+		query.Set("scope", strings.Join(cl.opts.Scopes, " "))
+	}
+	if st.Nonce != "" {
+		query.Set("nonce", st.Nonce)
+	}
+	u.RawQuery = query.Encode()
+
+	if err := cl.setState(c, st); err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+// Exchange 使用授权码与 PKCE verifier 换取令牌
+func (cl *Client) Exchange(ctx context.Context, code, codeVerifier string) (*Token, error) {
+	return cl.requestToken(ctx, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {cl.opts.RedirectURI},
+		"code_verifier": {codeVerifier},
+	})
+}
+
+// Refresh 使用刷新令牌换取新令牌，scope 为空时沿用原授权范围
+func (cl *Client) Refresh(ctx context.Context, refreshToken string, scopes ...string) (*Token, error) {
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	}
+	if len(scopes) > 0 {
+		form.Set("scope", strings.Join(scopes, " "))
+	}
+	return cl.requestToken(ctx, form)
+}
+
+// requestToken 请求令牌端点。有客户端密钥时使用 client_secret_basic 认证，否则在请求体中携带 client_id
+func (cl *Client) requestToken(ctx context.Context, form url.Values) (*Token, error) {
+	if cl.opts.ClientSecret == "" {
+		form.Set("client_id", cl.opts.ClientID)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cl.opts.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTokenExchange, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if cl.opts.ClientSecret != "" {
+		// RFC 6749 §2.3.1 要求凭据先经过 application/x-www-form-urlencoded 编码
+		req.SetBasicAuth(url.QueryEscape(cl.opts.ClientID), url.QueryEscape(cl.opts.ClientSecret))
+	}
+
+	resp, err := cl.opts.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTokenExchange, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxTokenResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTokenExchange, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var oerr Error
+		if json.Unmarshal(body, &oerr) == nil && oerr.Code != "" {
+			return nil, fmt.Errorf("%w: %w", ErrTokenExchange, &oerr)
+		}
+		return nil, fmt.Errorf("%w: unexpected status %d", ErrTokenExchange, resp.StatusCode)
+	}
+
+	var tr tokenResponse
+	if err := json.Unmarshal(body, &tr); err != nil {
+		return nil, fmt.Errorf("%w: decode: %v", ErrTokenExchange, err)
+	}
+	if tr.AccessToken == "" {
+		return nil, fmt.Errorf("%w: missing access_token", ErrTokenExchange)
+	}
+
+	token := &Token{
+		AccessToken:  tr.AccessToken,
+		TokenType:    tr.TokenType,
+		RefreshToken: tr.RefreshToken,
+		Scope:        tr.Scope,
+		IDToken:      tr.IDToken,
+	}
+	if tr.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(tr.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
@@ -0,0 +1,53 @@
+package client
+
+import "errors"
+
+var (
+	// ErrMissingClientID 表示未配置 client_id
+	ErrMissingClientID = errors.New("oauth2 client: client id is required")
+
+	// ErrMissingEndpoint 表示未配置授权端点、令牌端点或回调地址
+	ErrMissingEndpoint = errors.New("oauth2 client: endpoint is required")
+
+	// ErrMissingCookieSecret 表示未配置登录状态 Cookie 的签名密钥
+	ErrMissingCookieSecret = errors.New("oauth2 client: cookie secret is required")
+
+	// ErrMissingVerifier 表示申请了 openid 却无法验证 ID 令牌（未配置 JWKS 地址与签发者）
+	ErrMissingVerifier = errors.New("oauth2 client: id token verifier is required for openid scope")
+
+	// ErrNilLoginHandler 表示注册回调端点时未配置登录成功回调
+	ErrNilLoginHandler = errors.New("oauth2 client: login handler is nil")
+
+	// ErrInvalidState 表示登录状态 Cookie 缺失、被篡改、已过期或 state 不匹配
+	ErrInvalidState = errors.New("oauth2 client: invalid state")
+
+	// ErrAuthorizationDenied 表示授权服务器在回调中返回了错误（如用户拒绝授权）
+	ErrAuthorizationDenied = errors.New("oauth2 client: authorization denied")
+
+	// ErrTokenExchange 表示请求令牌端点失败
+	ErrTokenExchange = errors.New("oauth2 client: token exchange failed")
+
+	// ErrMissingIDToken 表示申请了 openid 但令牌响应中没有 ID 令牌
+	ErrMissingIDToken = errors.New("oauth2 client: missing id token")
+
+	// ErrIDTokenInvalid 表示 ID 令牌验证失败（签名、iss、aud、nonce、at_hash 等），
+	// 包装了 IDTokenVerifier 返回的原始错误
+	ErrIDTokenInvalid = errors.New("oauth2 client: invalid id token")
+)
+
+// Error 授权服务器按 RFC 6749 返回的错误，可通过 errors.As 获取
+type Error struct {
+	// Code 错误码，如 invalid_grant
+	Code string `json:"error"`
+
+	// Description 错误说明
+	Description string `json:"error_description,omitempty"`
+}
+
+// Error 实现 error 接口
+func (e *Error) Error() string {
+	if e.Description == "" {
+		return e.Code
+	}
+	return e.Code + ": " + e.Description
+}
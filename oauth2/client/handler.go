@@ -0,0 +1,111 @@
+package client
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/3086953492/gokit/ginx/problem"
+)
+
+// LoginHandler 返回登录端点处理器：生成授权地址并重定向到授权服务器。
+// 查询参数 next 指定登录完成后的站内跳转路径，通过 Login.Next 传给登录成功回调。
+func (cl *Client) LoginHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authURL, err := cl.AuthCodeURL(c, c.Query("next"))
+		if err != nil {
+			cl.fail(c, err)
+			return
+		}
+		c.Redirect(http.StatusFound, authURL)
+		c.Abort()
+	}
+}
+
+// CallbackHandler 返回回调端点处理器：校验 state、换取令牌、验证 ID 令牌后调用登录成功回调。
+// 未配置 WithLoginHandler 时会 panic，应在路由注册阶段暴露配置错误。
+func (cl *Client) CallbackHandler() gin.HandlerFunc {
+	if cl.opts.OnLogin == nil {
+		panic(ErrNilLoginHandler)
+	}
+
+	return func(c *gin.Context) {
+		login, err := cl.callback(c)
+		if err != nil {
+			cl.fail(c, err)
+			return
+		}
+		if err := cl.opts.OnLogin(c, login); err != nil {
+			cl.fail(c, err)
+			return
+		}
+	}
+}
+
+// callback 处理授权服务器回调
+func (cl *Client) callback(c *gin.Context) (*Login, error) {
+	st, err := cl.takeState(c, c.Query("state"))
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(c.Query("state")), []byte(st.State)) != 1 {
+		return nil, ErrInvalidState
+	}
+
+	if code := c.Query("error"); code != "" {
+		return nil, fmt.Errorf("%w: %w", ErrAuthorizationDenied, &Error{Code: code, Description: c.Query("error_description")})
+	}
+	code := c.Query("code")
+	if code == "" {
+		return nil, fmt.Errorf("%w: missing code", ErrAuthorizationDenied)
+	}
+
+	ctx := c.Request.Context()
+	token, err := cl.Exchange(ctx, code, st.Verifier)
+	if err != nil {
+		return nil, err
+	}
+
+	login := &Login{Token: token, Next: st.Next}
+	if cl.verifier == nil {
+		return login, nil
+	}
+	if token.IDToken == "" {
+		return nil, ErrMissingIDToken
+	}
+	claims, err := cl.verifier.Verify(ctx, token.IDToken, st.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrIDTokenInvalid, err)
+	}
+	if err := claims.VerifyAccessToken(token.AccessToken); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrIDTokenInvalid, err)
+	}
+	login.Claims = claims
+	login.Subject = claims.Subject
+	return login, nil
+}
+
+// fail 输出登录失败响应：state 无效或授权被拒绝返回 400，换取令牌或验证 ID 令牌失败返回 502，
+// 其余错误（如登录成功回调返回的错误）返回 500
+func (cl *Client) fail(c *gin.Context, err error) {
+	if cl.opts.ErrorHandler != nil {
+		cl.opts.ErrorHandler(c, err)
+		c.Abort()
+		return
+	}
+
+	switch {
+	case errors.Is(err, ErrInvalidState):
+		problem.Fail(c, http.StatusBadRequest, "Bad Request", "the login session is invalid or expired", "")
+	case errors.Is(err, ErrAuthorizationDenied):
+		problem.Fail(c, http.StatusBadRequest, "Bad Request", "the authorization request was denied", "")
+	case errors.Is(err, ErrTokenExchange), errors.Is(err, ErrMissingIDToken), errors.Is(err, ErrIDTokenInvalid):
+		problem.Fail(c, http.StatusBadGateway, "Bad Gateway", "login with the authorization server failed", "")
+	default:
+		problem.Fail(c, http.StatusInternalServerError, "Internal Server Error", "login failed", "")
+	}
+	c.Abort()
+}
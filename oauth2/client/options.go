@@ -0,0 +1,215 @@
+package client
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/3086953492/gokit/config/types"
+	"github.com/3086953492/gokit/jwt"
+)
+
+// DefaultCookieName 默认登录状态 Cookie 名称前缀
+const DefaultCookieName = "oauth2_login"
+
+// goauth 授权服务器的默认端点路径，与 oauth2/server 包文档中的路由一致
+const (
+	goauthAuthorizePath = "/oauth/authorize"
+	goauthTokenPath     = "/oauth/token"
+	goauthJWKSPath      = "/.well-known/jwks.json"
+)
+
+// Options 客户端配置
+type Options struct {
+	// ClientID 客户端 ID，必填
+	ClientID string
+
+	// ClientSecret 客户端密钥，为空时作为公共客户端在请求体中携带 client_id
+	ClientSecret string
+
+	// RedirectURI 回调地址，必须与授权服务器注册的一致，必填
+	RedirectURI string
+
+	// AuthURL 授权端点，必填
+	AuthURL string
+
+	// TokenURL 令牌端点，必填
+	TokenURL string
+
+	// JWKSURL 授权服务器 JWKS 地址，用于验证 ID 令牌
+	JWKSURL string
+
+	// Issuer 授权服务器签发者标识，验证 ID 令牌的 iss
+	Issuer string
+
+	// Scopes 申请的 scope，默认 openid
+	Scopes []string
+
+	// IDTokenVerifier 自定义 ID 令牌验证器，配置后忽略 JWKSURL 与 Issuer
+	IDTokenVerifier *jwt.IDTokenVerifier
+
+	// HTTPClient 请求令牌端点与 JWKS 使用的 HTTP 客户端，默认 10 秒超时
+	HTTPClient *http.Client
+
+	// CookieName 登录状态 Cookie 名称前缀，默认 "oauth2_login"，实际名称追加 "_" 与 state
+	CookieName string
+
+	// CookieSecret 登录状态 Cookie 的 HMAC 签名密钥，必填
+	CookieSecret []byte
+
+	// CookiePath 登录状态 Cookie 路径，默认 "/"
+	CookiePath string
+
+	// CookieSecure 登录状态 Cookie 是否仅通过 HTTPS 传输，默认 false（生产环境建议开启）
+	CookieSecure bool
+
+	// LoginTimeout 从发起登录到完成回调的最长时间，默认 10 分钟
+	LoginTimeout time.Duration
+
+	// OnLogin 登录成功回调，回调端点必填
+	OnLogin LoginFunc
+
+	// ErrorHandler 登录失败时的自定义处理，默认输出 Problem 响应，处理函数无需再调用 c.Abort
+	ErrorHandler func(c *gin.Context, err error)
+}
+
+// defaultOptions 返回带有合理默认值的 Options
+func defaultOptions() *Options {
+	return &Options{
+		Scopes:       []string{"openid"},
+		HTTPClient:   &http.Client{Timeout: 10 * time.Second},
+		CookieName:   DefaultCookieName,
+		CookiePath:   "/",
+		LoginTimeout: 10 * time.Minute,
+	}
+}
+
+// Option 配置函数类型
+type Option func(*Options)
+
+// WithGoauthConfig 使用 goauth 配置：设置 client_id、client_secret、redirect_uri，
+// 并以 BackendBaseURL 作为签发者，推导授权、令牌与 JWKS 端点
+func WithGoauthConfig(cfg types.GoauthConfig) Option {
+	return func(o *Options) {
+		o.ClientID = cfg.ClientID
+		o.ClientSecret = cfg.ClientSecret
+		o.RedirectURI = cfg.RedirectURI
+		if base := strings.TrimRight(cfg.BackendBaseURL, "/"); base != "" {
+			o.Issuer = base
+			o.AuthURL = base + goauthAuthorizePath
+			o.TokenURL = base + goauthTokenPath
+			o.JWKSURL = base + goauthJWKSPath
+		}
+	}
+}
+
+// WithClientCredentials 设置客户端 ID 与密钥
+func WithClientCredentials(clientID, clientSecret string) Option {
+	return func(o *Options) {
+		o.ClientID = clientID
+		o.ClientSecret = clientSecret
+	}
+}
+
+// WithRedirectURI 设置回调地址
+func WithRedirectURI(uri string) Option {
+	return func(o *Options) {
+		o.RedirectURI = uri
+	}
+}
+
+// WithEndpoints 设置授权端点与令牌端点
+func WithEndpoints(authURL, tokenURL string) Option {
+	return func(o *Options) {
+		o.AuthURL = authURL
+		o.TokenURL = tokenURL
+	}
+}
+
+// WithIssuer 设置授权服务器签发者与 JWKS 地址，用于验证 ID 令牌
+func WithIssuer(issuer, jwksURL string) Option {
+	return func(o *Options) {
+		o.Issuer = issuer
+		o.JWKSURL = jwksURL
+	}
+}
+
+// WithScopes 设置申请的 scope
+func WithScopes(scopes ...string) Option {
+	return func(o *Options) {
+		o.Scopes = scopes
+	}
+}
+
+// WithIDTokenVerifier 设置自定义 ID 令牌验证器
+func WithIDTokenVerifier(v *jwt.IDTokenVerifier) Option {
+	return func(o *Options) {
+		o.IDTokenVerifier = v
+	}
+}
+
+// WithHTTPClient 设置 HTTP 客户端
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *Options) {
+		if client != nil {
+			o.HTTPClient = client
+		}
+	}
+}
+
+// WithCookieName 设置登录状态 Cookie 名称前缀
+func WithCookieName(name string) Option {
+	return func(o *Options) {
+		if name != "" {
+			o.CookieName = name
+		}
+	}
+}
+
+// WithCookieSecret 设置登录状态 Cookie 的签名密钥
+func WithCookieSecret(secret string) Option {
+	return func(o *Options) {
+		o.CookieSecret = []byte(secret)
+	}
+}
+
+// WithCookiePath 设置登录状态 Cookie 路径
+func WithCookiePath(path string) Option {
+	return func(o *Options) {
+		if path != "" {
+			o.CookiePath = path
+		}
+	}
+}
+
+// WithCookieSecure 设置登录状态 Cookie 是否仅通过 HTTPS 传输
+func WithCookieSecure(secure bool) Option {
+	return func(o *Options) {
+		o.CookieSecure = secure
+	}
+}
+
+// WithLoginTimeout 设置从发起登录到完成回调的最长时间
+func WithLoginTimeout(d time.Duration) Option {
+	return func(o *Options) {
+		if d > 0 {
+			o.LoginTimeout = d
+		}
+	}
+}
+
+// WithLoginHandler 设置登录成功回调
+func WithLoginHandler(fn LoginFunc) Option {
+	return func(o *Options) {
+		o.OnLogin = fn
+	}
+}
+
+// WithErrorHandler 设置登录失败时的自定义处理
+func WithErrorHandler(fn func(c *gin.Context, err error)) Option {
+	return func(o *Options) {
+		o.ErrorHandler = fn
+	}
+}
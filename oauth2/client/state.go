@@ -0,0 +1,119 @@
+package client
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// loginState 发起登录时保存、回调时校验的状态
+type loginState struct {
+	State    string `json:"s"`
+	Nonce    string `json:"n,omitempty"`
+	Verifier string `json:"v"`
+	Next     string `json:"r,omitempty"`
+	Expires  int64  `json:"e"`
+}
+
+// setState 将登录状态签名后写入 Cookie，格式为 base64url(JSON) + "." + base64url(HMAC-SHA256)。
+// Cookie 名称包含 state，使多个标签页中并行发起的登录互不覆盖。
+func (cl *Client) setState(c *gin.Context, st *loginState) error {
+	payload, err := json.Marshal(st)
+	if err != nil {
+		return err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	value := encoded + "." + base64.RawURLEncoding.EncodeToString(cl.sign(encoded))
+	cl.writeCookie(c, cl.stateCookieName(st.State), value, int(cl.opts.LoginTimeout.Seconds()))
+	return nil
+}
+
+// takeState 读取并清除 state 对应的登录状态 Cookie，缺失、签名无效或已过期时返回 ErrInvalidState
+func (cl *Client) takeState(c *gin.Context, state string) (*loginState, error) {
+	if !validState(state) {
+		return nil, ErrInvalidState
+	}
+	name := cl.stateCookieName(state)
+	value, err := c.Cookie(name)
+	if err != nil || value == "" {
+		return nil, ErrInvalidState
+	}
+	// 登录状态只能使用一次
+	cl.writeCookie(c, name, "", -1)
+
+	encoded, sig, ok := strings.Cut(value, ".")
+	if !ok {
+		return nil, ErrInvalidState
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, cl.sign(encoded)) {
+		return nil, ErrInvalidState
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidState
+	}
+
+	var st loginState
+	if err := json.Unmarshal(payload, &st); err != nil {
+		return nil, ErrInvalidState
+	}
+	if time.Now().Unix() > st.Expires {
+		return nil, ErrInvalidState
+	}
+	return &st, nil
+}
+
+// sign 计算 HMAC-SHA256 签名
+func (cl *Client) sign(data string) []byte {
+	h := hmac.New(sha256.New, cl.opts.CookieSecret)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// stateCookieName 返回 state 对应的登录状态 Cookie 名称，形如 "oauth2_login_<state>"
+func (cl *Client) stateCookieName(state string) string {
+	return cl.opts.CookieName + "_" + state
+}
+
+// validState 校验回调中的 state 与发起登录时生成的格式一致，避免以任意输入拼接 Cookie 名称
+func validState(state string) bool {
+	if len(state) != stateLength {
+		return false
+	}
+	for i := 0; i < len(state); i++ {
+		ch := state[i]
+		if !(ch >= 'A' && ch <= 'Z' || ch >= 'a' && ch <= 'z' || ch >= '0' && ch <= '9' || ch == '-' || ch == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// writeCookie 写入登录状态 Cookie。
+// 回调是由授权服务器发起的跨站顶级导航，SameSite 使用 Lax 以保证 Cookie 随回调请求发送。
+func (cl *Client) writeCookie(c *gin.Context, name, value string, maxAge int) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     cl.opts.CookiePath,
+		MaxAge:   maxAge,
+		Secure:   cl.opts.CookieSecure,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// safeNext 仅接受站内绝对路径，防止开放重定向
+func safeNext(next string) string {
+	if !strings.HasPrefix(next, "/") || strings.HasPrefix(next, "//") || strings.HasPrefix(next, "/\\") {
+		return ""
+	}
+	return next
+}
@@ -0,0 +1,78 @@
+// Package client 提供 OAuth 2.0 / OpenID Connect 客户端（依赖方），用于接入 goauth 等授权服务器登录。
+//
+// 登录端点生成 state、nonce 与 PKCE verifier，写入签名 Cookie 后重定向到授权服务器；
+// 回调端点校验 state、用授权码换取令牌、验证 ID 令牌，再将登录结果交给应用回调。
+//
+//	oc, err := client.NewClient(
+//		client.WithGoauthConfig(cfg.Goauth),
+//		client.WithCookieSecret(cookieSecret),
+//		client.WithLoginHandler(func(c *gin.Context, login *client.Login) error {
+//			s := session.From(c)
+//			if err := s.Regenerate(); err != nil {
+//				return err
+//			}
+//			s.Set("user_id", login.Subject)
+//			c.Redirect(http.StatusFound, login.NextOr("/"))
+//			return nil
+//		}),
+//	)
+//
+//	r.GET("/auth/login", oc.LoginHandler())
+//	r.GET("/auth/callback", oc.CallbackHandler())
+package client
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/3086953492/gokit/jwt"
+)
+
+// LoginFunc 登录成功回调，负责建立本地会话并输出响应；返回错误时交由 ErrorHandler 处理
+type LoginFunc func(c *gin.Context, login *Login) error
+
+// Token 令牌端点返回的令牌
+type Token struct {
+	AccessToken  string
+	TokenType    string
+	RefreshToken string
+	Scope        string
+	IDToken      string
+
+	// Expiry 访问令牌过期时间，授权服务器未返回 expires_in 时为零值
+	Expiry time.Time
+}
+
+// Login 一次成功登录的结果
+type Login struct {
+	// Token 换取到的令牌
+	Token *Token
+
+	// Claims 已验证的 ID 令牌声明，未申请 openid 时为 nil
+	Claims *jwt.IDTokenClaims
+
+	// Subject 用户标识（ID 令牌的 sub），未申请 openid 时为空
+	Subject string
+
+	// Next 发起登录时携带的站内跳转路径，未携带时为空
+	Next string
+}
+
+// NextOr 返回登录后的跳转路径，未携带时返回 fallback
+func (l *Login) NextOr(fallback string) string {
+	if l.Next == "" {
+		return fallback
+	}
+	return l.Next
+}
+
+// tokenResponse RFC 6749 §5.1 令牌响应
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+	Scope        string `json:"scope"`
+	IDToken      string `json:"id_token"`
+}
@@ -0,0 +1,201 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/3086953492/gokit/security/random"
+)
+
+// codeLength 授权码长度，URL 安全字符集下约 256 位熵
+const codeLength = 43
+
+// authorizeParams 授权请求参数
+var authorizeParams = []string{
+	"client_id", "redirect_uri", "response_type", "scope", "state", "nonce", "code_challenge", "code_challenge_method",
+}
+
+// AuthorizeHandler 返回授权端点处理器（RFC 6749 §4.1.1），同时支持 GET 与 POST。
+//
+// client_id 或 redirect_uri 无效时直接输出 400 JSON 错误，不会重定向；
+// 其余错误以 error、error_description、state 参数重定向回客户端。
+// 未配置 WithAuthenticator 时会 panic，应在路由注册阶段暴露配置错误。
+func (s *Server) AuthorizeHandler() gin.HandlerFunc {
+	if s.opts.Authenticate == nil {
+		panic(ErrNilAuthenticator)
+	}
+
+	return func(c *gin.Context) {
+		if name := duplicateParam(c, authorizeParams...); name != "" {
+			writeError(c, NewError(ErrorInvalidRequest, "duplicate parameter "+name))
+			return
+		}
+
+		client, redirectURI, explicit, oerr := s.authorizeTarget(c)
+		if oerr != nil {
+			writeError(c, oerr)
+			return
+		}
+
+		state := c.Request.FormValue("state")
+		req, oerr := s.authorizeRequest(c, client, redirectURI)
+		if oerr != nil {
+			redirectError(c, redirectURI, state, oerr)
+			return
+		}
+
+		userID, ok := s.opts.Authenticate(c)
+		if !ok {
+			c.Abort()
+			return
+		}
+		req.UserID = userID
+
+		if s.opts.Consent != nil {
+			requested := slices.Clone(req.Scopes)
+			result, err := s.opts.Consent(c, req)
+			if err != nil {
+				redirectError(c, redirectURI, state, serverError())
+				return
+			}
+			switch result {
+			case ConsentDenied:
+				redirectError(c, redirectURI, state, NewError(ErrorAccessDenied, "the user denied the request"))
+				return
+			case ConsentPending:
+				c.Abort()
+				return
+			}
+			// 授权确认回调只能缩小申请的范围
+			if req.Scopes, oerr = narrowScopes(req.Scopes, requested); oerr != nil {
+				redirectError(c, redirectURI, state, oerr)
+				return
+			}
+		}
+
+		code, err := random.URLSafe(codeLength)
+		if err != nil {
+			redirectError(c, redirectURI, state, serverError())
+			return
+		}
+		data := &AuthorizationCode{
+			ClientID:      client.ID,
+			UserID:        req.UserID,
+			Scope:         strings.Join(req.Scopes, " "),
+			CodeChallenge: req.CodeChallenge,
+			Nonce:         req.Nonce,
+			AuthTime:      time.Now(),
+		}
+		if explicit {
+			data.RedirectURI = redirectURI
+		}
+		if err := s.codes.Save(c.Request.Context(), code, data, s.opts.CodeTTL); err != nil {
+			redirectError(c, redirectURI, state, serverError())
+			return
+		}
+
+		redirect(c, redirectURI, url.Values{"code": {code}}, state)
+	}
+}
+
+// authorizeTarget 校验 client_id 与 redirect_uri。
+// 未携带 redirect_uri 时，仅注册了一个回调地址的客户端使用该地址。
+func (s *Server) authorizeTarget(c *gin.Context) (*Client, string, bool, *Error) {
+	clientID := c.Request.FormValue("client_id")
+	if clientID == "" {
+		return nil, "", false, NewError(ErrorInvalidRequest, "missing client_id")
+	}
+	client, err := s.clients.GetClient(c.Request.Context(), clientID)
+	if err != nil {
+		if errors.Is(err, ErrClientNotFound) {
+			return nil, "", false, NewError(ErrorInvalidRequest, "unknown client_id")
+		}
+		return nil, "", false, serverError()
+	}
+
+	redirectURI := c.Request.FormValue("redirect_uri")
+	if redirectURI == "" {
+		if len(client.RedirectURIs) != 1 {
+			return nil, "", false, NewError(ErrorInvalidRequest, "missing redirect_uri")
+		}
+		return client, client.RedirectURIs[0], false, nil
+	}
+	if !client.AllowsRedirectURI(redirectURI) {
+		return nil, "", false, NewError(ErrorInvalidRequest, "redirect_uri is not registered")
+	}
+	return client, redirectURI, true, nil
+}
+
+// authorizeRequest 校验 response_type、授权类型、scope 与 PKCE 参数
+func (s *Server) authorizeRequest(c *gin.Context, client *Client, redirectURI string) (*AuthorizeRequest, *Error) {
+	if rt := c.Request.FormValue("response_type"); rt != "code" {
+		return nil, NewError(ErrorUnsupportedResponseType, "only response_type=code is supported")
+	}
+	if !client.AllowsGrant(GrantAuthorizationCode) {
+		return nil, NewError(ErrorUnauthorizedClient, "the client is not allowed to use authorization_code")
+	}
+
+	scopes, oerr := resolveScopes(c.Request.FormValue("scope"), client.Scopes)
+	if oerr != nil {
+		return nil, oerr
+	}
+
+	challenge := c.Request.FormValue("code_challenge")
+	method := c.Request.FormValue("code_challenge_method")
+	switch {
+	case challenge == "" && (client.IsPublic() || s.opts.RequirePKCE):
+		return nil, NewError(ErrorInvalidRequest, "code_challenge is required")
+	case challenge == "" && method != "":
+		return nil, NewError(ErrorInvalidRequest, "code_challenge_method without code_challenge")
+	case challenge != "" && method != PKCEMethodS256:
+		// 未携带 method 时按 RFC 7636 视为 plain，不予支持
+		return nil, NewError(ErrorInvalidRequest, "code_challenge_method must be S256")
+	case challenge != "" && !validCodeChallenge(challenge):
+		return nil, NewError(ErrorInvalidRequest, "malformed code_challenge")
+	}
+
+	return &AuthorizeRequest{
+		Client:        client,
+		RedirectURI:   redirectURI,
+		Scopes:        scopes,
+		State:         c.Request.FormValue("state"),
+		Nonce:         c.Request.FormValue("nonce"),
+		CodeChallenge: challenge,
+	}, nil
+}
+
+// redirectError 按 RFC 6749 §4.1.2.1 将错误重定向回客户端
+func redirectError(c *gin.Context, redirectURI, state string, e *Error) {
+	params := url.Values{"error": {e.Code}}
+	if e.Description != "" {
+		params.Set("error_description", e.Description)
+	}
+	redirect(c, redirectURI, params, state)
+}
+
+// redirect 在回调地址原有查询参数上追加 params 与 state 并重定向
+func redirect(c *gin.Context, redirectURI string, params url.Values, state string) {
+	u, err := url.Parse(redirectURI)
+	if err != nil {
+		writeError(c, NewError(ErrorInvalidRequest, "malformed redirect_uri"))
+		return
+	}
+	if state != "" {
+		params.Set("state", state)
+	}
+	query := u.Query()
+	for k, v := range params {
+		query[k] = v
+	}
+	u.RawQuery = query.Encode()
+
+	noStore(c)
+	c.Redirect(http.StatusFound, u.String())
+	c.Abort()
+}
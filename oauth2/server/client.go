@@ -0,0 +1,48 @@
+package server
+
+import (
+	"context"
+	"sync"
+)
+
+// ClientStore 客户端注册表，通常由业务方基于数据库实现
+type ClientStore interface {
+	// GetClient 按 ID 查询客户端，不存在时返回 ErrClientNotFound
+	GetClient(ctx context.Context, id string) (*Client, error)
+}
+
+var _ ClientStore = (*MemoryClientStore)(nil)
+
+// MemoryClientStore 进程内客户端注册表，适用于客户端固定写在配置中的场景与测试
+type MemoryClientStore struct {
+	mu      sync.RWMutex
+	clients map[string]*Client
+}
+
+// NewMemoryClientStore 创建内存客户端注册表
+func NewMemoryClientStore(clients ...*Client) *MemoryClientStore {
+	s := &MemoryClientStore{clients: make(map[string]*Client, len(clients))}
+	for _, c := range clients {
+		s.clients[c.ID] = c
+	}
+	return s
+}
+
+// Register 注册或替换客户端
+func (s *MemoryClientStore) Register(c *Client) {
+	s.mu.Lock()
+	s.clients[c.ID] = c
+	s.mu.Unlock()
+}
+
+// GetClient 实现 ClientStore 接口
+func (s *MemoryClientStore) GetClient(ctx context.Context, id string) (*Client, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c, ok := s.clients[id]
+	if !ok {
+		return nil, ErrClientNotFound
+	}
+	return c, nil
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/3086953492/gokit/redis"
+)
+
+// CodeStore 一次性授权码存储
+type CodeStore interface {
+	// Save 保存授权码，ttl 后过期
+	Save(ctx context.Context, code string, data *AuthorizationCode, ttl time.Duration) error
+
+	// Consume 原子地取出并删除授权码，不存在、已使用或已过期时返回 ErrCodeNotFound
+	Consume(ctx context.Context, code string) (*AuthorizationCode, error)
+}
+
+var (
+	_ CodeStore = (*RedisCodeStore)(nil)
+	_ CodeStore = (*MemoryCodeStore)(nil)
+)
+
+// codeConsumeScript 读取并删除授权码，兼容不支持 GETDEL 的 Redis 版本
+// KEYS[1] 授权码 key
+var codeConsumeScript = redis.NewScript(`
+	local v = redis.call("get", KEYS[1])
+	if v then
+		redis.call("del", KEYS[1])
+	end
+	return v
+`)
+
+// RedisCodeStore 基于 redis.Manager 的授权码存储，key 形如 "oauth2:code:<sha256(code)>"，
+// 存储授权码的摘要而非明文。
+type RedisCodeStore struct {
+	mgr    *redis.Manager
+	prefix string
+}
+
+// NewRedisCodeStore 创建 Redis 授权码存储
+func NewRedisCodeStore(mgr *redis.Manager) *RedisCodeStore {
+	return &RedisCodeStore{mgr: mgr, prefix: "oauth2:code:"}
+}
+
+// Save 实现 CodeStore 接口
+func (s *RedisCodeStore) Save(ctx context.Context, code string, data *AuthorizationCode, ttl time.Duration) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("oauth2: encode authorization code: %w", err)
+	}
+	return s.mgr.SetBytes(ctx, s.prefix+codeKey(code), raw, ttl)
+}
+
+// Consume 实现 CodeStore 接口，使用 Lua 脚本保证授权码只能被使用一次
+func (s *RedisCodeStore) Consume(ctx context.Context, code string) (*AuthorizationCode, error) {
+	result, err := s.mgr.EvalScript(ctx, codeConsumeScript, []string{s.prefix + codeKey(code)})
+	if err != nil {
+		return nil, err
+	}
+	raw, ok := result.(string)
+	if !ok {
+		return nil, ErrCodeNotFound
+	}
+
+	var data AuthorizationCode
+	if err := json.Unmarshal([]byte(raw), &data); err != nil {
+		return nil, fmt.Errorf("oauth2: decode authorization code: %w", err)
+	}
+	return &data, nil
+}
+
+// MemoryCodeStore 进程内授权码存储，适用于单实例部署与测试
+type MemoryCodeStore struct {
+	mu    sync.Mutex
+	codes map[string]codeEntry
+}
+
+type codeEntry struct {
+	data     AuthorizationCode
+	expireAt time.Time
+}
+
+// NewMemoryCodeStore 创建内存授权码存储
+func NewMemoryCodeStore() *MemoryCodeStore {
+	return &MemoryCodeStore{codes: make(map[string]codeEntry)}
+}
+
+// Save 实现 CodeStore 接口
+func (s *MemoryCodeStore) Save(ctx context.Context, code string, data *AuthorizationCode, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for k, e := range s.codes {
+		if now.After(e.expireAt) {
+			delete(s.codes, k)
+		}
+	}
+	s.codes[codeKey(code)] = codeEntry{data: *data, expireAt: now.Add(ttl)}
+	return nil
+}
+
+// Consume 实现 CodeStore 接口
+func (s *MemoryCodeStore) Consume(ctx context.Context, code string) (*AuthorizationCode, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := codeKey(code)
+	e, ok := s.codes[key]
+	delete(s.codes, key)
+	if !ok || time.Now().After(e.expireAt) {
+		return nil, ErrCodeNotFound
+	}
+	data := e.data
+	return &data, nil
+}
+
+// codeKey 返回授权码的 SHA-256 摘要
+func codeKey(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
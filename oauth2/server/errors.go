@@ -0,0 +1,75 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+)
+
+// RFC 6749 §4.1.2.1 与 §5.2 定义的错误码
+const (
+	ErrorInvalidRequest          = "invalid_request"
+	ErrorInvalidClient           = "invalid_client"
+	ErrorInvalidGrant            = "invalid_grant"
+	ErrorUnauthorizedClient      = "unauthorized_client"
+	ErrorUnsupportedGrantType    = "unsupported_grant_type"
+	ErrorUnsupportedResponseType = "unsupported_response_type"
+	ErrorInvalidScope            = "invalid_scope"
+	ErrorAccessDenied            = "access_denied"
+	ErrorServerError             = "server_error"
+
+	// ErrorUnsupportedTokenType 授权服务器不支持撤销该类型的令牌（RFC 7009 §2.2.1）
+	ErrorUnsupportedTokenType = "unsupported_token_type"
+)
+
+var (
+	// ErrNilClientStore 表示 ClientStore 为 nil
+	ErrNilClientStore = errors.New("oauth2: client store is nil")
+
+	// ErrNilCodeStore 表示 CodeStore 为 nil
+	ErrNilCodeStore = errors.New("oauth2: code store is nil")
+
+	// ErrNilTokenManager 表示 jwt.Manager 为 nil
+	ErrNilTokenManager = errors.New("oauth2: token manager is nil")
+
+	// ErrNilAuthenticator 表示注册授权端点时未配置用户认证回调
+	ErrNilAuthenticator = errors.New("oauth2: authenticator is nil")
+
+	// ErrClientNotFound 表示客户端不存在
+	ErrClientNotFound = errors.New("oauth2: client not found")
+
+	// ErrCodeNotFound 表示授权码不存在、已使用或已过期
+	ErrCodeNotFound = errors.New("oauth2: authorization code not found")
+)
+
+// Error RFC 6749 错误响应，可作为 error 返回并由处理器按规范输出。
+type Error struct {
+	// Code 错误码，如 invalid_request
+	Code string `json:"error"`
+
+	// Description 面向开发者的错误说明
+	Description string `json:"error_description,omitempty"`
+
+	// Status HTTP 状态码
+	Status int `json:"-"`
+}
+
+// NewError 创建错误响应，状态码由错误码推断：
+// invalid_client 为 401，server_error 为 500，其余为 400。
+func NewError(code, description string) *Error {
+	status := http.StatusBadRequest
+	switch code {
+	case ErrorInvalidClient:
+		status = http.StatusUnauthorized
+	case ErrorServerError:
+		status = http.StatusInternalServerError
+	}
+	return &Error{Code: code, Description: description, Status: status}
+}
+
+// Error 实现 error 接口
+func (e *Error) Error() string {
+	if e.Description == "" {
+		return "oauth2: " + e.Code
+	}
+	return "oauth2: " + e.Code + ": " + e.Description
+}
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/3086953492/gokit/jwt"
+)
+
+// introspectParams 内省请求参数
+var introspectParams = []string{"token", "token_type_hint", "client_id", "client_secret"}
+
+// IntrospectionHandler 返回令牌内省端点处理器（RFC 7662），应注册为 POST 路由。
+// 调用方须为机密客户端（通常是资源服务器），通过 client_secret_basic 或 client_secret_post 认证。
+//
+// 令牌经 jwt.Manager 验证并查询撤销状态；无效、过期或已撤销时返回 {"active": false}。
+// 启用刷新令牌轮换时，已被轮换替换或所属家族已撤销的刷新令牌同样视为无效，与令牌端点的判断一致。
+// 仅访问令牌与刷新令牌可能报告为有效，ID 令牌等其他令牌一律返回 {"active": false}；
+// 刷新令牌仅对其所属客户端报告为有效，避免向其他客户端泄露授权信息。
+// token_type_hint 仅作提示，服务端始终按令牌自身类型解析。
+func (s *Server) IntrospectionHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if name := duplicateParam(c, introspectParams...); name != "" {
+			writeError(c, NewError(ErrorInvalidRequest, "duplicate parameter "+name))
+			return
+		}
+
+		client, oerr := s.authenticateClient(c)
+		if oerr != nil {
+			writeError(c, oerr)
+			return
+		}
+		if client.IsPublic() {
+			_, _, basic := c.Request.BasicAuth()
+			writeError(c, invalidClient(c, basic))
+			return
+		}
+
+		token := c.PostForm("token")
+		if token == "" {
+			writeError(c, NewError(ErrorInvalidRequest, "missing token"))
+			return
+		}
+
+		claims, err := s.tokens.ParseTokenContext(c.Request.Context(), token)
+		if err != nil {
+			if tokenRejected(err) {
+				writeIntrospection(c, &IntrospectionResponse{Active: false})
+				return
+			}
+			writeError(c, serverError())
+			return
+		}
+		if !issuedToken(claims) || claims.TokenType == jwt.RefreshToken && claims.ClientID != client.ID {
+			writeIntrospection(c, &IntrospectionResponse{Active: false})
+			return
+		}
+
+		writeIntrospection(c, introspection(claims))
+	}
+}
+
+// issuedToken 判断令牌是否为授权服务器签发的访问令牌或刷新令牌
+func issuedToken(claims *jwt.Claims) bool {
+	return claims.TokenType == jwt.AccessToken || claims.TokenType == jwt.RefreshToken
+}
+
+// introspection 由令牌声明构造有效令牌的内省响应
+func introspection(claims *jwt.Claims) *IntrospectionResponse {
+	resp := &IntrospectionResponse{
+		Active:   true,
+		Scope:    claims.Scope,
+		ClientID: claims.ClientID,
+		Subject:  claims.Subject,
+		Audience: claims.Audience,
+		Issuer:   claims.Issuer,
+		JTI:      claims.ID,
+	}
+	if claims.TokenType == jwt.AccessToken {
+		resp.TokenType = "Bearer"
+	}
+	if claims.ExpiresAt != nil {
+		resp.ExpiresAt = claims.ExpiresAt.Unix()
+	}
+	if claims.IssuedAt != nil {
+		resp.IssuedAt = claims.IssuedAt.Unix()
+	}
+	if claims.NotBefore != nil {
+		resp.NotBefore = claims.NotBefore.Unix()
+	}
+	return resp
+}
+
+// writeIntrospection 输出内省响应
+func writeIntrospection(c *gin.Context, resp *IntrospectionResponse) {
+	noStore(c)
+	c.JSON(http.StatusOK, resp)
+}
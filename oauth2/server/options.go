@@ -0,0 +1,92 @@
+package server
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/3086953492/gokit/jwt"
+	"github.com/3086953492/gokit/security/password"
+)
+
+// DefaultCodeTTL 默认授权码有效期
+const DefaultCodeTTL = 5 * time.Minute
+
+// Options 授权服务器配置
+type Options struct {
+	// CodeTTL 授权码有效期，默认 5 分钟
+	CodeTTL time.Duration
+
+	// RequirePKCE 是否要求所有客户端使用 PKCE，默认 true；为 false 时仅公共客户端必须使用
+	RequirePKCE bool
+
+	// Authenticate 返回当前登录用户，未登录时返回 false 并自行输出响应（如重定向到登录页），授权端点必填
+	Authenticate func(c *gin.Context) (userID string, ok bool)
+
+	// Consent 授权确认回调，为 nil 时自动同意（适用于第一方客户端）
+	Consent ConsentFunc
+
+	// ExtraResolver 授权码换取令牌时加载访问令牌扩展字段，可选。
+	// 刷新令牌模式使用 jwt.Manager 自身的 ExtraResolver，两者通常应为同一实现。
+	ExtraResolver jwt.ExtraResolver
+
+	// Passwords 校验客户端密钥使用的密码管理器，默认使用 bcrypt 默认配置
+	Passwords *password.Manager
+}
+
+// defaultOptions 返回带有合理默认值的 Options
+func defaultOptions() *Options {
+	return &Options{
+		CodeTTL:     DefaultCodeTTL,
+		RequirePKCE: true,
+	}
+}
+
+// Option 配置函数类型
+type Option func(*Options)
+
+// WithCodeTTL 设置授权码有效期
+func WithCodeTTL(ttl time.Duration) Option {
+	return func(o *Options) {
+		if ttl > 0 {
+			o.CodeTTL = ttl
+		}
+	}
+}
+
+// WithRequirePKCE 设置是否要求所有客户端使用 PKCE
+func WithRequirePKCE(required bool) Option {
+	return func(o *Options) {
+		o.RequirePKCE = required
+	}
+}
+
+// WithAuthenticator 设置授权端点的用户认证回调
+func WithAuthenticator(fn func(c *gin.Context) (userID string, ok bool)) Option {
+	return func(o *Options) {
+		o.Authenticate = fn
+	}
+}
+
+// WithConsent 设置授权确认回调
+func WithConsent(fn ConsentFunc) Option {
+	return func(o *Options) {
+		o.Consent = fn
+	}
+}
+
+// WithExtraResolver 设置授权码换取令牌时的扩展字段加载回调
+func WithExtraResolver(resolver jwt.ExtraResolver) Option {
+	return func(o *Options) {
+		o.ExtraResolver = resolver
+	}
+}
+
+// WithPasswordManager 设置校验客户端密钥使用的密码管理器
+func WithPasswordManager(mgr *password.Manager) Option {
+	return func(o *Options) {
+		if mgr != nil {
+			o.Passwords = mgr
+		}
+	}
+}
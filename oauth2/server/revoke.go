@@ -0,0 +1,68 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/3086953492/gokit/jwt"
+)
+
+// revokeParams 撤销请求参数
+var revokeParams = []string{"token", "token_type_hint", "client_id", "client_secret"}
+
+// RevocationHandler 返回令牌撤销端点处理器（RFC 7009），应注册为 POST 路由。
+// 撤销记录写入 jwt.Manager 配置的 Revoker，未配置时返回 unsupported_token_type；
+// 撤销启用轮换的刷新令牌时同时撤销其所属家族。
+//
+// 客户端只能撤销签发给自己的令牌；令牌无效、过期、已撤销或不是访问令牌与刷新令牌时按 RFC 7009 §2.2 返回 200。
+func (s *Server) RevocationHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if name := duplicateParam(c, revokeParams...); name != "" {
+			writeError(c, NewError(ErrorInvalidRequest, "duplicate parameter "+name))
+			return
+		}
+
+		client, oerr := s.authenticateClient(c)
+		if oerr != nil {
+			writeError(c, oerr)
+			return
+		}
+
+		token := c.PostForm("token")
+		if token == "" {
+			writeError(c, NewError(ErrorInvalidRequest, "missing token"))
+			return
+		}
+
+		ctx := c.Request.Context()
+		claims, err := s.tokens.ParseTokenContext(ctx, token)
+		if err != nil {
+			if tokenRejected(err) {
+				c.Status(http.StatusOK)
+				return
+			}
+			writeError(c, serverError())
+			return
+		}
+		if !issuedToken(claims) {
+			c.Status(http.StatusOK)
+			return
+		}
+		if claims.ClientID != client.ID {
+			writeError(c, NewError(ErrorUnauthorizedClient, "the token was not issued to this client"))
+			return
+		}
+
+		if err := s.tokens.RevokeToken(ctx, token); err != nil {
+			if errors.Is(err, jwt.ErrRevokerNotConfigured) {
+				writeError(c, NewError(ErrorUnsupportedTokenType, "token revocation is not supported"))
+				return
+			}
+			writeError(c, serverError())
+			return
+		}
+		c.Status(http.StatusOK)
+	}
+}
@@ -0,0 +1,238 @@
+package server
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/3086953492/gokit/jwt"
+	"github.com/3086953492/gokit/security/password"
+)
+
+// Server OAuth 2.0 授权服务器，线程安全
+type Server struct {
+	clients ClientStore
+	codes   CodeStore
+	tokens  *jwt.Manager
+	opts    *Options
+
+	// dummyHash 客户端不存在时用于比较的哈希，使其与密钥校验耗时相近，避免通过响应时间枚举客户端 ID
+	dummyHash string
+}
+
+// NewServer 创建授权服务器，令牌由 tokens 签发。
+// 刷新令牌模式要求 tokens 配置了 ExtraResolver；签发 ID 令牌要求配置了 SubjectManager。
+func NewServer(clients ClientStore, codes CodeStore, tokens *jwt.Manager, opts ...Option) (*Server, error) {
+	if clients == nil {
+		return nil, ErrNilClientStore
+	}
+	if codes == nil {
+		return nil, ErrNilCodeStore
+	}
+	if tokens == nil {
+		return nil, ErrNilTokenManager
+	}
+
+	o := defaultOptions()
+	for _, fn := range opts {
+		fn(o)
+	}
+	if o.Passwords == nil {
+		pm, err := password.NewManager()
+		if err != nil {
+			return nil, err
+		}
+		o.Passwords = pm
+	}
+	dummyHash, err := o.Passwords.Hash("oauth2-unknown-client")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Server{clients: clients, codes: codes, tokens: tokens, opts: o, dummyHash: dummyHash}, nil
+}
+
+// authenticateClient 按 RFC 6749 §2.3.1 认证客户端，支持 client_secret_basic 与 client_secret_post；
+// 公共客户端仅需提供 client_id
+func (s *Server) authenticateClient(c *gin.Context) (*Client, *Error) {
+	id, secret, basic := c.Request.BasicAuth()
+	if basic {
+		// Basic 认证中的凭据先经过 application/x-www-form-urlencoded 编码
+		var err1, err2 error
+		id, err1 = url.QueryUnescape(id)
+		secret, err2 = url.QueryUnescape(secret)
+		if err1 != nil || err2 != nil {
+			return nil, invalidClient(c, true)
+		}
+		if c.PostForm("client_secret") != "" {
+			return nil, NewError(ErrorInvalidRequest, "multiple client authentication methods")
+		}
+		if formID := c.PostForm("client_id"); formID != "" && formID != id {
+			return nil, NewError(ErrorInvalidRequest, "client_id mismatch")
+		}
+	} else {
+		id = c.PostForm("client_id")
+		secret = c.PostForm("client_secret")
+	}
+	if id == "" {
+		return nil, invalidClient(c, basic)
+	}
+
+	client, err := s.clients.GetClient(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, ErrClientNotFound) {
+			_ = s.opts.Passwords.Compare(s.dummyHash, secret)
+			return nil, invalidClient(c, basic)
+		}
+		return nil, serverError()
+	}
+
+	if client.IsPublic() {
+		if secret != "" {
+			return nil, invalidClient(c, basic)
+		}
+		return client, nil
+	}
+	if secret == "" || s.opts.Passwords.Compare(client.SecretHash, secret) != nil {
+		return nil, invalidClient(c, basic)
+	}
+	return client, nil
+}
+
+// invalidClient 返回 invalid_client 错误，客户端使用 Basic 认证时附带 WWW-Authenticate 头
+func invalidClient(c *gin.Context, basic bool) *Error {
+	if basic {
+		c.Header("WWW-Authenticate", `Basic realm="oauth2"`)
+	}
+	return NewError(ErrorInvalidClient, "client authentication failed")
+}
+
+// serverError 返回不泄露内部细节的 server_error
+func serverError() *Error {
+	return NewError(ErrorServerError, "")
+}
+
+// writeError 按 RFC 6749 §5.2 输出 JSON 错误
+func writeError(c *gin.Context, e *Error) {
+	noStore(c)
+	c.AbortWithStatusJSON(e.Status, e)
+}
+
+// noStore 禁止缓存包含令牌或凭据的响应
+func noStore(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+}
+
+// resolveScopes 校验申请的 scope 均在允许范围内，未申请时授予全部允许的 scope
+func resolveScopes(requested string, allowed []string) ([]string, *Error) {
+	scopes := strings.Fields(requested)
+	if len(scopes) == 0 {
+		return slices.Clone(allowed), nil
+	}
+
+	out := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !validScopeToken(scope) {
+			return nil, NewError(ErrorInvalidScope, fmt.Sprintf("malformed scope %q", scope))
+		}
+		if !slices.Contains(allowed, scope) {
+			return nil, NewError(ErrorInvalidScope, fmt.Sprintf("scope %q is not allowed", scope))
+		}
+		if !slices.Contains(out, scope) {
+			out = append(out, scope)
+		}
+	}
+	return out, nil
+}
+
+// narrowScopes 校验授权确认后的 scope 均在申请范围内，并去除重复项
+func narrowScopes(granted, requested []string) ([]string, *Error) {
+	out := make([]string, 0, len(granted))
+	for _, scope := range granted {
+		if !slices.Contains(requested, scope) {
+			return nil, NewError(ErrorInvalidScope, fmt.Sprintf("scope %q was not requested", scope))
+		}
+		if !slices.Contains(out, scope) {
+			out = append(out, scope)
+		}
+	}
+	return out, nil
+}
+
+// validScopeToken 校验 RFC 6749 §3.3 的 scope-token 字符集
+func validScopeToken(scope string) bool {
+	for i := 0; i < len(scope); i++ {
+		ch := scope[i]
+		if ch < 0x21 || ch > 0x7e || ch == '"' || ch == '\\' {
+			return false
+		}
+	}
+	return scope != ""
+}
+
+// validCodeChallenge 校验 S256 挑战值：SHA-256 摘要的 Base64URL 编码，固定 43 个字符
+func validCodeChallenge(challenge string) bool {
+	decoded, err := base64.RawURLEncoding.DecodeString(challenge)
+	return err == nil && len(decoded) == sha256.Size
+}
+
+// verifyCodeVerifier 按 RFC 7636 §4.6 校验 code_verifier
+func verifyCodeVerifier(verifier, challenge string) bool {
+	if len(verifier) < 43 || len(verifier) > 128 {
+		return false
+	}
+	for i := 0; i < len(verifier); i++ {
+		ch := verifier[i]
+		if !(ch >= 'A' && ch <= 'Z' || ch >= 'a' && ch <= 'z' || ch >= '0' && ch <= '9' ||
+			ch == '-' || ch == '.' || ch == '_' || ch == '~') {
+			return false
+		}
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	computed := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(computed), []byte(challenge)) == 1
+}
+
+// grantError 将 jwt 令牌错误映射为 invalid_grant，其余视为 server_error
+func grantError(err error) *Error {
+	if tokenRejected(err) {
+		return NewError(ErrorInvalidGrant, "refresh token is invalid, expired or revoked")
+	}
+	return serverError()
+}
+
+// tokenRejected 判断错误是否表示令牌本身无效、过期或已撤销，而非服务端故障
+func tokenRejected(err error) bool {
+	return errors.Is(err, jwt.ErrTokenExpired) ||
+		errors.Is(err, jwt.ErrInvalidToken) ||
+		errors.Is(err, jwt.ErrInvalidTokenType) ||
+		errors.Is(err, jwt.ErrTokenRevoked) ||
+		errors.Is(err, jwt.ErrRefreshTokenReused) ||
+		errors.Is(err, jwt.ErrKeyNotFound)
+}
+
+// duplicateParam 返回请求中重复出现的参数名（RFC 6749 §3.1、§3.2 要求参数不得重复）
+func duplicateParam(c *gin.Context, names ...string) string {
+	_ = c.Request.ParseForm()
+	for _, name := range names {
+		if len(c.Request.Form[name]) > 1 {
+			return name
+		}
+	}
+	return ""
+}
+
+// writeToken 输出令牌响应
+func writeToken(c *gin.Context, resp *TokenResponse) {
+	noStore(c)
+	c.JSON(http.StatusOK, resp)
+}
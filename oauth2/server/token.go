@@ -0,0 +1,213 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/3086953492/gokit/jwt"
+)
+
+// tokenParams 令牌请求参数
+var tokenParams = []string{
+	"grant_type", "code", "redirect_uri", "code_verifier", "refresh_token", "scope", "client_id", "client_secret",
+}
+
+// TokenHandler 返回令牌端点处理器（RFC 6749 §3.2），应注册为 POST 路由。
+// 支持 authorization_code、client_credentials 与 refresh_token 三种授权类型。
+func (s *Server) TokenHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if name := duplicateParam(c, tokenParams...); name != "" {
+			writeError(c, NewError(ErrorInvalidRequest, "duplicate parameter "+name))
+			return
+		}
+
+		client, oerr := s.authenticateClient(c)
+		if oerr != nil {
+			writeError(c, oerr)
+			return
+		}
+
+		grantType := c.PostForm("grant_type")
+		var resp *TokenResponse
+		switch grantType {
+		case GrantAuthorizationCode, GrantClientCredentials, GrantRefreshToken:
+			if !client.AllowsGrant(grantType) {
+				writeError(c, NewError(ErrorUnauthorizedClient, "the client is not allowed to use "+grantType))
+				return
+			}
+		case "":
+			writeError(c, NewError(ErrorInvalidRequest, "missing grant_type"))
+			return
+		default:
+			writeError(c, NewError(ErrorUnsupportedGrantType, ""))
+			return
+		}
+
+		switch grantType {
+		case GrantAuthorizationCode:
+			resp, oerr = s.exchangeCode(c, client)
+		case GrantClientCredentials:
+			resp, oerr = s.clientCredentials(c, client)
+		case GrantRefreshToken:
+			resp, oerr = s.refreshToken(c, client)
+		}
+		if oerr != nil {
+			writeError(c, oerr)
+			return
+		}
+		writeToken(c, resp)
+	}
+}
+
+// exchangeCode 授权码模式（RFC 6749 §4.1.3，RFC 7636 §4.5）
+func (s *Server) exchangeCode(c *gin.Context, client *Client) (*TokenResponse, *Error) {
+	code := c.PostForm("code")
+	if code == "" {
+		return nil, NewError(ErrorInvalidRequest, "missing code")
+	}
+
+	ctx := c.Request.Context()
+	data, err := s.codes.Consume(ctx, code)
+	if err != nil {
+		if errors.Is(err, ErrCodeNotFound) {
+			return nil, NewError(ErrorInvalidGrant, "authorization code is invalid or expired")
+		}
+		return nil, serverError()
+	}
+	if data.ClientID != client.ID {
+		return nil, NewError(ErrorInvalidGrant, "authorization code was issued to another client")
+	}
+	if data.RedirectURI != "" && c.PostForm("redirect_uri") != data.RedirectURI {
+		return nil, NewError(ErrorInvalidGrant, "redirect_uri mismatch")
+	}
+
+	verifier := c.PostForm("code_verifier")
+	switch {
+	case data.CodeChallenge != "" && !verifyCodeVerifier(verifier, data.CodeChallenge):
+		return nil, NewError(ErrorInvalidGrant, "code_verifier mismatch")
+	case data.CodeChallenge == "" && verifier != "":
+		return nil, NewError(ErrorInvalidGrant, "code_verifier without code_challenge")
+	}
+
+	extra, err := s.resolveExtra(ctx, data.UserID)
+	if err != nil {
+		return nil, serverError()
+	}
+
+	grant := jwt.Grant{ClientID: client.ID, Scope: data.Scope}
+	resp := &TokenResponse{TokenType: "Bearer", ExpiresIn: int64(s.tokens.AccessTTL().Seconds()), Scope: data.Scope}
+	if client.AllowsGrant(GrantRefreshToken) {
+		resp.AccessToken, resp.RefreshToken, err = s.tokens.GenerateTokenPairForGrant(ctx, data.UserID, extra, grant)
+	} else {
+		resp.AccessToken, err = s.tokens.GenerateAccessTokenForGrant(data.UserID, extra, grant)
+	}
+	if err != nil {
+		return nil, serverError()
+	}
+
+	if containsScope(data.Scope, ScopeOpenID) {
+		resp.IDToken, err = s.tokens.GenerateIDToken(jwt.IDTokenParams{
+			UserID:      data.UserID,
+			ClientID:    client.ID,
+			Sector:      client.Sector,
+			Nonce:       data.Nonce,
+			AuthTime:    data.AuthTime,
+			AccessToken: resp.AccessToken,
+		})
+		if err != nil {
+			return nil, serverError()
+		}
+	}
+	return resp, nil
+}
+
+// clientCredentials 客户端凭证模式（RFC 6749 §4.4），令牌 sub 为客户端 ID，不签发刷新令牌
+func (s *Server) clientCredentials(c *gin.Context, client *Client) (*TokenResponse, *Error) {
+	if client.IsPublic() {
+		return nil, NewError(ErrorUnauthorizedClient, "public clients cannot use client_credentials")
+	}
+
+	scopes, oerr := resolveScopes(c.PostForm("scope"), client.Scopes)
+	if oerr != nil {
+		return nil, oerr
+	}
+	scope := strings.Join(scopes, " ")
+
+	accessToken, err := s.tokens.GenerateAccessTokenForGrant(client.ID, nil, jwt.Grant{ClientID: client.ID, Scope: scope})
+	if err != nil {
+		return nil, serverError()
+	}
+	return &TokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(s.tokens.AccessTTL().Seconds()),
+		Scope:       scope,
+	}, nil
+}
+
+// refreshToken 刷新令牌模式（RFC 6749 §6），可申请不超出原授权范围的更小 scope
+func (s *Server) refreshToken(c *gin.Context, client *Client) (*TokenResponse, *Error) {
+	refreshToken := c.PostForm("refresh_token")
+	if refreshToken == "" {
+		return nil, NewError(ErrorInvalidRequest, "missing refresh_token")
+	}
+
+	ctx := c.Request.Context()
+	claims, err := s.tokens.ParseRefreshTokenContext(ctx, refreshToken)
+	if errors.Is(err, jwt.ErrTokenRevoked) {
+		// 已被轮换替换的旧令牌交由 RefreshTokenPair 执行重用检测并撤销整个家族，
+		// 令牌不是家族当前令牌，轮换必然失败，不会签发新令牌
+		if _, _, err = s.tokens.RefreshTokenPair(ctx, refreshToken); err == nil {
+			return nil, serverError()
+		}
+		return nil, grantError(err)
+	}
+	if err != nil {
+		return nil, grantError(err)
+	}
+	if claims.ClientID != client.ID {
+		return nil, NewError(ErrorInvalidGrant, "refresh token was issued to another client")
+	}
+
+	scope := claims.Scope
+	if requested := c.PostForm("scope"); requested != "" {
+		scopes, oerr := resolveScopes(requested, strings.Fields(claims.Scope))
+		if oerr != nil {
+			return nil, oerr
+		}
+		scope = strings.Join(scopes, " ")
+	}
+
+	accessToken, newRefreshToken, err := s.tokens.RefreshTokenPairWithScope(ctx, refreshToken, scope)
+	if err != nil {
+		return nil, grantError(err)
+	}
+	return &TokenResponse{
+		AccessToken:  accessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(s.tokens.AccessTTL().Seconds()),
+		RefreshToken: newRefreshToken,
+		Scope:        scope,
+	}, nil
+}
+
+// resolveExtra 加载访问令牌扩展字段，未配置时返回 nil
+func (s *Server) resolveExtra(ctx context.Context, userID string) (map[string]any, error) {
+	if s.opts.ExtraResolver == nil {
+		return nil, nil
+	}
+	return s.opts.ExtraResolver.ResolveExtra(ctx, userID)
+}
+
+// containsScope 判断以空格分隔的 scope 是否包含指定值
+func containsScope(scope, target string) bool {
+	for _, s := range strings.Fields(scope) {
+		if s == target {
+			return true
+		}
+	}
+	return false
+}
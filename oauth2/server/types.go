@@ -0,0 +1,161 @@
+// Package server 提供 OAuth 2.0 授权服务器的构建模块。
+//
+// 支持授权码模式（强制 PKCE S256）、客户端凭证模式与刷新令牌模式，
+// 令牌由 jwt.Manager 签发；scope 包含 openid 时同时签发 OIDC ID 令牌。
+// 授权端点、令牌端点、内省端点（RFC 7662）与撤销端点（RFC 7009）以 gin 处理器提供，
+// 错误按 RFC 6749 输出。
+//
+//	srv, err := server.NewServer(clients, server.NewRedisCodeStore(rdb), jwtMgr,
+//		server.WithAuthenticator(func(c *gin.Context) (string, bool) {
+//			if userID := session.From(c).GetString("user_id"); userID != "" {
+//				return userID, true
+//			}
+//			c.Redirect(http.StatusFound, "/login?next="+url.QueryEscape(c.Request.URL.String()))
+//			return "", false
+//		}),
+//	)
+//
+//	r.GET("/oauth/authorize", srv.AuthorizeHandler())
+//	r.POST("/oauth/authorize", srv.AuthorizeHandler())
+//	r.POST("/oauth/token", srv.TokenHandler())
+//	r.POST("/oauth/introspect", srv.IntrospectionHandler())
+//	r.POST("/oauth/revoke", srv.RevocationHandler())
+package server
+
+import (
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 支持的授权类型
+const (
+	GrantAuthorizationCode = "authorization_code"
+	GrantClientCredentials = "client_credentials"
+	GrantRefreshToken      = "refresh_token"
+)
+
+// PKCEMethodS256 唯一支持的 PKCE 变换方式
+const PKCEMethodS256 = "S256"
+
+// ScopeOpenID 请求 OIDC ID 令牌的 scope
+const ScopeOpenID = "openid"
+
+// Client 已注册的 OAuth 2.0 客户端
+type Client struct {
+	// ID 客户端 ID
+	ID string
+
+	// SecretHash 客户端密钥的 bcrypt 哈希（可由 security/password 生成），为空表示公共客户端
+	SecretHash string
+
+	// RedirectURIs 已注册的回调地址，授权请求中的 redirect_uri 必须与其中之一完全一致
+	RedirectURIs []string
+
+	// GrantTypes 允许使用的授权类型
+	GrantTypes []string
+
+	// Scopes 允许申请的 scope，请求未携带 scope 时授予全部
+	Scopes []string
+
+	// Sector 签发 ID 令牌时 pairwise sub 的 sector 标识，为空时使用 public sub
+	Sector string
+}
+
+// IsPublic 返回是否为公共客户端（无客户端密钥，如 SPA、移动应用）
+func (c *Client) IsPublic() bool {
+	return c.SecretHash == ""
+}
+
+// AllowsGrant 返回客户端是否允许使用指定授权类型
+func (c *Client) AllowsGrant(grantType string) bool {
+	return slices.Contains(c.GrantTypes, grantType)
+}
+
+// AllowsRedirectURI 返回 redirect_uri 是否已注册
+func (c *Client) AllowsRedirectURI(uri string) bool {
+	return slices.Contains(c.RedirectURIs, uri)
+}
+
+// AuthorizeRequest 已通过校验的授权请求，传给授权确认回调
+type AuthorizeRequest struct {
+	// Client 发起请求的客户端
+	Client *Client
+
+	// UserID 已认证的用户
+	UserID string
+
+	// RedirectURI 授权结果的回调地址
+	RedirectURI string
+
+	// Scopes 申请的 scope，授权确认回调可将其缩小为用户实际同意的范围；
+	// 回调写入申请范围之外的 scope 时授权请求以 invalid_scope 失败，重复项会被去除
+	Scopes []string
+
+	// State 客户端传入的 state，原样返回
+	State string
+
+	// Nonce OIDC nonce，写入 ID 令牌
+	Nonce string
+
+	// CodeChallenge PKCE 挑战值
+	CodeChallenge string
+}
+
+// AuthorizationCode 授权码关联的授权信息
+type AuthorizationCode struct {
+	ClientID string `json:"client_id"`
+	UserID   string `json:"user_id"`
+
+	// RedirectURI 授权请求中显式携带的 redirect_uri，令牌请求必须携带相同的值；
+	// 授权请求未携带时为空
+	RedirectURI string `json:"redirect_uri,omitempty"`
+
+	Scope         string    `json:"scope,omitempty"`
+	CodeChallenge string    `json:"code_challenge,omitempty"`
+	Nonce         string    `json:"nonce,omitempty"`
+	AuthTime      time.Time `json:"auth_time"`
+}
+
+// ConsentResult 授权确认结果
+type ConsentResult int
+
+const (
+	// ConsentGranted 用户同意授权，签发授权码
+	ConsentGranted ConsentResult = iota
+
+	// ConsentDenied 用户拒绝授权，以 access_denied 重定向回客户端
+	ConsentDenied
+
+	// ConsentPending 尚未确认，回调已自行输出响应（如渲染授权确认页）
+	ConsentPending
+)
+
+// ConsentFunc 授权确认回调。确认页提交时应再次请求授权端点，由回调根据表单返回结果。
+type ConsentFunc func(c *gin.Context, req *AuthorizeRequest) (ConsentResult, error)
+
+// TokenResponse RFC 6749 §5.1 令牌响应
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+	IDToken      string `json:"id_token,omitempty"`
+}
+
+// IntrospectionResponse RFC 7662 §2.2 内省响应，令牌无效时仅包含 active=false
+type IntrospectionResponse struct {
+	Active    bool     `json:"active"`
+	Scope     string   `json:"scope,omitempty"`
+	ClientID  string   `json:"client_id,omitempty"`
+	TokenType string   `json:"token_type,omitempty"`
+	Subject   string   `json:"sub,omitempty"`
+	Audience  []string `json:"aud,omitempty"`
+	Issuer    string   `json:"iss,omitempty"`
+	ExpiresAt int64    `json:"exp,omitempty"`
+	IssuedAt  int64    `json:"iat,omitempty"`
+	NotBefore int64    `json:"nbf,omitempty"`
+	JTI       string   `json:"jti,omitempty"`
+}
@@ -0,0 +1,310 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// electionCampaignScript 竞选领导者
+// 成功时递增并返回 fencing token，失败返回 0
+// KEYS[1] 领导者 key，KEYS[2] fencing 计数器
+// ARGV[1] 候选者 ID，ARGV[2] 租约毫秒数
+var electionCampaignScript = NewScript(`
+	local leader = redis.call("get", KEYS[1])
+	if leader and leader ~= ARGV[1] then
+		return 0
+	end
+	redis.call("set", KEYS[1], ARGV[1], "PX", ARGV[2])
+	return redis.call("incr", KEYS[2])
+`)
+
+// ElectionOption 配置 Election 实例
+type ElectionOption func(*electionConfig)
+
+type electionConfig struct {
+	ttl           time.Duration
+	renewInterval time.Duration
+	onElected     func(ctx context.Context, token int64)
+	onRevoked     func()
+}
+
+// WithElectionTTL 设置领导者租约时长，默认 15 秒
+// 领导者崩溃后，其他候选者最迟在 ttl 之后接任
+func WithElectionTTL(ttl time.Duration) ElectionOption {
+	return func(c *electionConfig) {
+		c.ttl = ttl
+	}
+}
+
+// WithRenewInterval 设置租约续期间隔，默认为 ttl 的三分之一
+func WithRenewInterval(d time.Duration) ElectionOption {
+	return func(c *electionConfig) {
+		c.renewInterval = d
+	}
+}
+
+// WithOnElected 设置当选回调
+// 回调在独立 goroutine 中执行，ctx 会在失去领导权时取消，token 为本任期的 fencing token
+func WithOnElected(fn func(ctx context.Context, token int64)) ElectionOption {
+	return func(c *electionConfig) {
+		c.onElected = fn
+	}
+}
+
+// WithOnRevoked 设置失去领导权回调（租约丢失或主动 Resign 时触发）
+func WithOnRevoked(fn func()) ElectionOption {
+	return func(c *electionConfig) {
+		c.onRevoked = fn
+	}
+}
+
+// Election 基于 Redis 租约的领导者选举。
+// 同一 name 下同一时刻至多一个候选者成为领导者，领导者自动续期租约，
+// 每次当选都会获得一个单调递增的 fencing token，可用于保护下游写入。
+//
+// 使用示例：
+//
+//	e := mgr.NewElection("cron", redis.WithOnElected(func(ctx context.Context, token int64) {
+//		runMaintenance(ctx, token)
+//	}))
+//	token, err := e.Campaign(ctx)
+//	defer e.Resign(context.Background())
+type Election struct {
+	manager *Manager
+	keys    []string
+	id      string
+	cfg     electionConfig
+
+	mu     sync.Mutex
+	leader bool
+	token  int64
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	// deadline 本地认定的租约到期时间，取发起续期前的时刻加 ttl 再减去安全余量
+	deadline time.Time
+
+	// campaigning 是否有进行中的 Campaign，保证同一时刻至多一个竞选与续期 goroutine
+	campaigning bool
+}
+
+// NewElection 创建领导者选举候选者
+func (m *Manager) NewElection(name string, opts ...ElectionOption) *Election {
+	cfg := electionConfig{ttl: 15 * time.Second}
+	for _, o := range opts {
+		o(&cfg)
+	}
+	if cfg.renewInterval <= 0 {
+		cfg.renewInterval = cfg.ttl / 3
+	}
+
+	tagged := "{" + name + "}"
+	return &Election{
+		manager: m,
+		keys:    []string{tagged + ":leader", tagged + ":fence"},
+		id:      uuid.New().String(),
+		cfg:     cfg,
+	}
+}
+
+// ID 返回当前候选者 ID
+func (e *Election) ID() string {
+	return e.id
+}
+
+// Campaign 参与竞选，阻塞直到成为领导者或 ctx 结束。
+// 当选后返回 fencing token，并在后台自动续期租约。
+// 已是领导者时直接返回当前 token；同一候选者已有进行中的竞选时返回 ErrCampaignInProgress。
+func (e *Election) Campaign(ctx context.Context) (int64, error) {
+	e.mu.Lock()
+	if e.campaigning {
+		e.mu.Unlock()
+		return 0, ErrCampaignInProgress
+	}
+	if e.leader && e.validLocked() {
+		token := e.token
+		e.mu.Unlock()
+		return token, nil
+	}
+	e.campaigning = true
+	pending := e.done
+	if !e.leader {
+		pending = nil
+	}
+	e.mu.Unlock()
+
+	defer func() {
+		e.mu.Lock()
+		e.campaigning = false
+		e.mu.Unlock()
+	}()
+
+	// 本地租约已到期但续期 goroutine 尚未退出时，等待其撤销领导权后再竞选
+	if pending != nil {
+		select {
+		case <-pending:
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+
+	var (
+		token int64
+		start time.Time
+	)
+	err := e.manager.waitAcquire(ctx, func(ctx context.Context) error {
+		start = time.Now()
+		result, err := e.manager.EvalScript(ctx, electionCampaignScript, e.keys, e.id, e.cfg.ttl.Milliseconds())
+		if err != nil {
+			return err
+		}
+		token, _ = result.(int64)
+		if token == 0 {
+			return ErrLockAcquireFailed
+		}
+		return nil
+	}, func(ctx context.Context) error {
+		_, err := e.manager.EvalScript(ctx, releaseLockScript, e.keys[:1], e.id)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	leaderCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	done := make(chan struct{})
+
+	e.mu.Lock()
+	e.leader = true
+	e.token = token
+	e.cancel = cancel
+	e.done = done
+	e.deadline = leaseDeadline(start, e.cfg.ttl)
+	e.mu.Unlock()
+
+	go e.keepAlive(leaderCtx, done)
+	if e.cfg.onElected != nil {
+		go e.cfg.onElected(leaderCtx, token)
+	}
+	return token, nil
+}
+
+// Resign 主动放弃领导权并释放租约，非领导者调用时直接返回 nil
+func (e *Election) Resign(ctx context.Context) error {
+	e.mu.Lock()
+	if !e.leader {
+		e.mu.Unlock()
+		return nil
+	}
+	cancel, done := e.cancel, e.done
+	e.mu.Unlock()
+
+	cancel()
+	<-done
+
+	_, err := e.manager.EvalScript(ctx, releaseLockScript, e.keys[:1], e.id)
+	return err
+}
+
+// IsLeader 返回当前是否为领导者。
+// 本地租约到期（续期未能及时成功）后立即返回 false，不等待续期 goroutine 撤销领导权。
+func (e *Election) IsLeader() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.leader && e.validLocked()
+}
+
+// Token 返回本任期的 fencing token，非领导者或本地租约已到期时返回 0
+func (e *Election) Token() int64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.leader || !e.validLocked() {
+		return 0
+	}
+	return e.token
+}
+
+// validLocked 返回本地租约是否仍在有效期内，调用方须持有 e.mu
+func (e *Election) validLocked() bool {
+	return time.Now().Before(e.deadline)
+}
+
+// leaseDeadline 计算本地租约到期时间。
+// 以发起请求前的时刻为起点并扣除 ttl 的十分之一作为时钟漂移与网络延迟的安全余量，
+// 保证本地认定的租约先于 Redis 中的 key 到期
+func leaseDeadline(start time.Time, ttl time.Duration) time.Time {
+	return start.Add(ttl - ttl/10)
+}
+
+// Leader 返回当前领导者 ID，无领导者时返回空字符串
+func (e *Election) Leader(ctx context.Context) (string, error) {
+	data, err := e.manager.GetBytes(ctx, e.keys[0])
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// keepAlive 定期续期租约，租约丢失或续期超时后撤销领导权
+func (e *Election) keepAlive(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	defer e.revoke()
+
+	ticker := time.NewTicker(e.cfg.renewInterval)
+	defer ticker.Stop()
+
+	for {
+		e.mu.Lock()
+		deadline := e.deadline
+		e.mu.Unlock()
+
+		// 本地租约到期时立即撤销，不等待下一次续期
+		expiry := time.NewTimer(time.Until(deadline))
+		select {
+		case <-ctx.Done():
+			expiry.Stop()
+			return
+		case <-expiry.C:
+			return
+		case <-ticker.C:
+			expiry.Stop()
+		}
+
+		start := time.Now()
+		renewCtx, cancel := context.WithTimeout(ctx, e.cfg.renewInterval)
+		result, err := e.manager.EvalScript(renewCtx, refreshLockScript, e.keys[:1], e.id, e.cfg.ttl.Milliseconds())
+		cancel()
+
+		switch {
+		case err == nil && scriptOK(result):
+			e.mu.Lock()
+			e.deadline = leaseDeadline(start, e.cfg.ttl)
+			e.mu.Unlock()
+		case err == nil:
+			// 租约已被他人接管
+			return
+		case errors.Is(err, ErrClosed):
+			return
+		case time.Now().After(deadline):
+			// 续期持续失败且租约已到期，无法确认仍是领导者
+			return
+		}
+	}
+}
+
+// revoke 清理领导者状态并触发回调
+func (e *Election) revoke() {
+	e.mu.Lock()
+	e.leader = false
+	cancel := e.cancel
+	e.mu.Unlock()
+
+	cancel()
+	if e.cfg.onRevoked != nil {
+		e.cfg.onRevoked()
+	}
+}
@@ -14,5 +14,11 @@ var (
 
 	// ErrLockAcquireFailed 表示获取分布式锁失败
 	ErrLockAcquireFailed = errors.New("redis: lock acquire failed")
+
+	// ErrLockNotHeld 表示租约已过期或不由当前持有者持有
+	ErrLockNotHeld = errors.New("redis: lock not held")
+
+	// ErrCampaignInProgress 表示同一候选者已有进行中的竞选
+	ErrCampaignInProgress = errors.New("redis: campaign already in progress")
 )
 
@@ -0,0 +1,208 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// CommandEvent 描述一次 Redis 命令（或 pipeline）的执行情况
+type CommandEvent struct {
+	// Name 命令名（小写），如 "get"、"evalsha"；pipeline 为 "pipeline"
+	Name string
+
+	// Pipeline 是否为 pipeline / 事务批量执行
+	Pipeline bool
+
+	// Size 本次执行包含的命令数，单条命令为 1
+	Size int
+
+	// Duration 执行耗时（含网络往返）
+	Duration time.Duration
+
+	// Err 执行错误，key 不存在（redis.Nil）不视为错误
+	Err error
+
+	// PoolStats 命令执行完成时的连接池统计
+	PoolStats PoolStats
+}
+
+// PoolStats 连接池统计信息
+type PoolStats struct {
+	Hits       uint32 // 从池中命中空闲连接的次数
+	Misses     uint32 // 池中无空闲连接需新建的次数
+	Timeouts   uint32 // 等待连接超时的次数
+	TotalConns uint32 // 当前连接总数
+	IdleConns  uint32 // 当前空闲连接数
+	StaleConns uint32 // 已清理的失效连接数
+}
+
+// Hook 命令观测钩子，可用于上报延迟、错误率等指标
+// 实现方应尽快返回，避免阻塞命令执行路径
+type Hook interface {
+	// AfterCommand 在命令执行完成后调用
+	AfterCommand(ctx context.Context, ev *CommandEvent)
+}
+
+// HookFunc 是 Hook 的函数适配器
+type HookFunc func(ctx context.Context, ev *CommandEvent)
+
+// AfterCommand 实现 Hook 接口
+func (f HookFunc) AfterCommand(ctx context.Context, ev *CommandEvent) {
+	f(ctx, ev)
+}
+
+// Logger 日志接口，log/slog.Logger 自动满足此接口
+type Logger interface {
+	Warn(msg string, args ...any)
+}
+
+// NewSlowLogHook 创建慢命令日志钩子
+// 执行耗时达到 threshold 的命令会以 Warn 级别输出
+func NewSlowLogHook(threshold time.Duration, logger Logger) Hook {
+	return HookFunc(func(ctx context.Context, ev *CommandEvent) {
+		if ev.Duration < threshold {
+			return
+		}
+		args := []any{
+			"command", ev.Name,
+			"duration", ev.Duration,
+			"threshold", threshold,
+		}
+		if ev.Pipeline {
+			args = append(args, "size", ev.Size)
+		}
+		if ev.Err != nil {
+			args = append(args, "error", ev.Err)
+		}
+		logger.Warn("redis slow command", args...)
+	})
+}
+
+// HealthStatus 健康检查结果
+type HealthStatus struct {
+	// Latency PING 往返延迟
+	Latency time.Duration
+
+	// PoolSize 连接池容量
+	PoolSize int
+
+	// Saturation 连接池饱和度，即使用中的连接数 / PoolSize，取值 [0, 1]
+	Saturation float64
+
+	// PoolStats 连接池统计
+	PoolStats PoolStats
+}
+
+// HealthCheck 执行一次 PING 并返回延迟与连接池饱和度，适用于就绪探针。
+// PING 失败时仍返回连接池统计，同时返回错误。
+func (m *Manager) HealthCheck(ctx context.Context) (*HealthStatus, error) {
+	client, err := m.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	pingErr := client.Ping(ctx).Err()
+	latency := time.Since(start)
+
+	stats := toPoolStats(client.PoolStats())
+	status := &HealthStatus{
+		Latency:   latency,
+		PoolSize:  m.opts.PoolSize,
+		PoolStats: stats,
+	}
+	if m.opts.PoolSize > 0 {
+		inUse := int(stats.TotalConns) - int(stats.IdleConns)
+		if inUse < 0 {
+			inUse = 0
+		}
+		status.Saturation = float64(inUse) / float64(m.opts.PoolSize)
+	}
+
+	if pingErr != nil {
+		return status, fmt.Errorf("redis ping: %w", pingErr)
+	}
+	return status, nil
+}
+
+// instrumentHook 将 go-redis 钩子适配为 Hook 回调
+type instrumentHook struct {
+	client *redis.Client
+	hooks  []Hook
+}
+
+var _ redis.Hook = (*instrumentHook)(nil)
+
+// DialHook 实现 redis.Hook，不做处理
+func (h *instrumentHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+// ProcessHook 实现 redis.Hook，记录单条命令
+func (h *instrumentHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmd)
+		h.emit(ctx, &CommandEvent{
+			Name:     cmd.Name(),
+			Size:     1,
+			Duration: time.Since(start),
+			Err:      normalizeErr(err),
+		})
+		return err
+	}
+}
+
+// ProcessPipelineHook 实现 redis.Hook，记录 pipeline
+func (h *instrumentHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmds)
+		h.emit(ctx, &CommandEvent{
+			Name:     "pipeline",
+			Pipeline: true,
+			Size:     len(cmds),
+			Duration: time.Since(start),
+			Err:      normalizeErr(err),
+		})
+		return err
+	}
+}
+
+// emit 补充连接池统计后依次调用所有钩子
+func (h *instrumentHook) emit(ctx context.Context, ev *CommandEvent) {
+	ev.PoolStats = toPoolStats(h.client.PoolStats())
+	for _, hook := range h.hooks {
+		hook.AfterCommand(ctx, ev)
+	}
+}
+
+// normalizeErr 将 redis.Nil 视为成功
+func normalizeErr(err error) error {
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	return err
+}
+
+// toPoolStats 转换 go-redis 连接池统计
+func toPoolStats(s *redis.PoolStats) PoolStats {
+	if s == nil {
+		return PoolStats{}
+	}
+	return PoolStats{
+		Hits:       s.Hits,
+		Misses:     s.Misses,
+		Timeouts:   s.Timeouts,
+		TotalConns: s.TotalConns,
+		IdleConns:  s.IdleConns,
+		StaleConns: s.StaleConns,
+	}
+}
@@ -0,0 +1,54 @@
+package redis
+
+import "strings"
+
+// globReplacer 转义 SCAN MATCH 中的通配符元字符
+var globReplacer = strings.NewReplacer(
+	`\`, `\\`,
+	`*`, `\*`,
+	`?`, `\?`,
+	`[`, `\[`,
+	`]`, `\]`,
+)
+
+// prefixKey 为 key 添加命名空间前缀
+func (m *Manager) prefixKey(key string) string {
+	if m.opts.KeyPrefix == "" {
+		return key
+	}
+	return m.opts.KeyPrefix + key
+}
+
+// prefixKeys 为一组 key 添加命名空间前缀，返回新切片
+func (m *Manager) prefixKeys(keys []string) []string {
+	if m.opts.KeyPrefix == "" || len(keys) == 0 {
+		return keys
+	}
+	prefixed := make([]string, len(keys))
+	for i, k := range keys {
+		prefixed[i] = m.opts.KeyPrefix + k
+	}
+	return prefixed
+}
+
+// prefixPattern 为 SCAN 匹配模式添加命名空间前缀
+// 前缀中的通配符会被转义，避免误匹配其他命名空间
+func (m *Manager) prefixPattern(pattern string) string {
+	if m.opts.KeyPrefix == "" {
+		return pattern
+	}
+	return globReplacer.Replace(m.opts.KeyPrefix) + pattern
+}
+
+// stripKey 去除 key 的命名空间前缀
+func (m *Manager) stripKey(key string) string {
+	return strings.TrimPrefix(key, m.opts.KeyPrefix)
+}
+
+// stripPattern 去除 prefixPattern 添加的已转义前缀
+func (m *Manager) stripPattern(pattern string) string {
+	if m.opts.KeyPrefix == "" {
+		return pattern
+	}
+	return strings.TrimPrefix(pattern, globReplacer.Replace(m.opts.KeyPrefix))
+}
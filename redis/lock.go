@@ -7,6 +7,60 @@ import (
 	"github.com/google/uuid"
 )
 
+// releaseLockScript 仅当锁仍由当前持有者持有时删除锁
+var releaseLockScript = NewScript(`
+	if redis.call("get", KEYS[1]) == ARGV[1] then
+		return redis.call("del", KEYS[1])
+	else
+		return 0
+	end
+`)
+
+// refreshLockScript 仅当锁仍由当前持有者持有时续期
+var refreshLockScript = NewScript(`
+	if redis.call("get", KEYS[1]) == ARGV[1] then
+		return redis.call("pexpire", KEYS[1], ARGV[2])
+	else
+		return 0
+	end
+`)
+
+// builtinScripts 内置脚本，创建 Manager 时自动注册
+var builtinScripts = []*Script{
+	releaseLockScript,
+	refreshLockScript,
+	semAcquireScript,
+	semReleaseScript,
+	semRefreshScript,
+	rwReadAcquireScript,
+	rwWriteAcquireScript,
+	rwWriteReleaseScript,
+	electionCampaignScript,
+}
+
+// Locker 定义基于 Redis 的租约锁的通用操作，
+// DistributedLock、Semaphore、ReadLock、WriteLock 均实现此接口。
+type Locker interface {
+	// Acquire 尝试获取一次，失败返回 ErrLockAcquireFailed
+	Acquire(ctx context.Context) error
+
+	// AcquireWait 阻塞等待直到获取成功或 ctx 结束
+	AcquireWait(ctx context.Context) error
+
+	// Release 释放持有的租约
+	Release(ctx context.Context) error
+
+	// Refresh 续期租约，租约已失效时返回 ErrLockNotHeld
+	Refresh(ctx context.Context) error
+}
+
+var (
+	_ Locker = (*DistributedLock)(nil)
+	_ Locker = (*Semaphore)(nil)
+	_ Locker = (*ReadLock)(nil)
+	_ Locker = (*WriteLock)(nil)
+)
+
 // DistributedLock 分布式锁实现
 type DistributedLock struct {
 	manager *Manager
@@ -38,16 +92,72 @@ func (l *DistributedLock) Acquire(ctx context.Context) error {
 	return nil
 }
 
+// AcquireWait 阻塞等待获取锁，每隔 LockRetryInterval 重试一次
+// ctx 结束时返回 ctx.Err()
+func (l *DistributedLock) AcquireWait(ctx context.Context) error {
+	return l.manager.waitAcquire(ctx, l.Acquire, l.Release)
+}
+
 // Release 释放锁
 // 使用 Lua 脚本保证原子性，只有持有锁的进程才能释放
 func (l *DistributedLock) Release(ctx context.Context) error {
-	luaScript := `
-		if redis.call("get", KEYS[1]) == ARGV[1] then
-			return redis.call("del", KEYS[1])
-		else
-			return 0
-		end
-	`
-	_, err := l.manager.Eval(ctx, luaScript, []string{l.key}, l.value)
+	_, err := l.manager.EvalScript(ctx, releaseLockScript, []string{l.key}, l.value)
 	return err
 }
+
+// Refresh 将锁的过期时间重置为 expire
+// 锁已过期或被他人持有时返回 ErrLockNotHeld
+func (l *DistributedLock) Refresh(ctx context.Context) error {
+	result, err := l.manager.EvalScript(ctx, refreshLockScript, []string{l.key}, l.value, l.expire.Milliseconds())
+	if err != nil {
+		return err
+	}
+	if !scriptOK(result) {
+		return ErrLockNotHeld
+	}
+	return nil
+}
+
+// waitAcquire 循环调用 try 直到成功、出现非竞争错误或 ctx 结束
+// try 成功时总是返回 nil，即使 ctx 恰好同时结束，避免调用方误以为失败而不释放已获取的资源。
+// 因 ctx 结束而放弃时调用 release（可为 nil）：超时的脚本调用可能已在服务端执行，需撤销可能已获取的资源
+func (m *Manager) waitAcquire(ctx context.Context, try, release func(ctx context.Context) error) error {
+	ticker := time.NewTicker(m.opts.LockRetryInterval)
+	defer ticker.Stop()
+
+	for {
+		err := try(ctx)
+		if err == nil {
+			return nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			abandon(ctx, release)
+			return ctxErr
+		}
+		if err != ErrLockAcquireFailed {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			abandon(ctx, release)
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// abandon 放弃等待时执行清理，使用独立 ctx 保证清理能够执行
+func abandon(ctx context.Context, release func(ctx context.Context) error) {
+	if release == nil {
+		return
+	}
+	cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Second)
+	defer cancel()
+	_ = release(cleanupCtx)
+}
+
+// scriptOK 判断脚本返回值是否为 1
+func scriptOK(result any) bool {
+	n, _ := result.(int64)
+	return n == 1
+}
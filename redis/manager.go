@@ -15,86 +15,217 @@ type Manager struct {
 	opts   *Options
 	client *redis.Client
 
-	mu       sync.RWMutex
-	closed   bool
-	connOnce sync.Once
-	connErr  error
+	mu     sync.RWMutex
+	closed bool
+	state  ConnState
+
+	// connMu 串行化连接建立过程，避免并发 Connect 重复拨号
+	connMu      sync.Mutex
+	monitorOnce sync.Once
+	stopMonitor chan struct{}
+	monitorDone chan struct{}
+
+	// monitoring 后台监控是否已启动，Close 时据此等待其退出
+	monitoring bool
+
+	scriptsMu sync.RWMutex
+	scripts   map[string]*Script
+
+	// subs 托管中的订阅，Close 时统一取消
+	subs map[*Subscription]struct{}
 }
 
 // NewManager 创建一个新的 Redis Manager。
-// 调用 NewManager 后需要显式调用 Connect(ctx) 来建立连接。
+// 调用 NewManager 后需要显式调用 Connect(ctx) 来建立连接；
+// 启用 WithLazyConnect 时也可以不调用，首次执行命令时自动连接。
 func NewManager(opts ...Option) *Manager {
 	options := defaultOptions()
 	for _, opt := range opts {
 		opt(options)
 	}
-	return &Manager{
-		opts: options,
+	options.normalize()
+	m := &Manager{
+		opts:        options,
+		state:       StateDisconnected,
+		stopMonitor: make(chan struct{}),
+		monitorDone: make(chan struct{}),
+		scripts:     make(map[string]*Script),
+		subs:        make(map[*Subscription]struct{}),
 	}
+	for _, s := range builtinScripts {
+		m.scripts[s.hash] = s
+	}
+	return m
 }
 
 // Connect 建立到 Redis 的连接。
-// 此方法是幂等的，多次调用只会建立一次连接。
+// 已连接时直接返回 nil；连接失败会按 ConnectRetries 与退避策略重试，
+// 全部失败后返回错误，后续再次调用 Connect 会重新尝试。
+// 连接成功后会通过 SCRIPT LOAD 预加载所有已注册的 Lua 脚本。
+// 配置了 MonitorInterval 时，首次调用 Connect 后启动后台连接监控。
 // 如果 Manager 已关闭，返回 ErrClosed。
 func (m *Manager) Connect(ctx context.Context) error {
+	defer m.startMonitor()
+	return m.connect(ctx, m.opts.ConnectRetries)
+}
+
+// connect 按给定重试次数建立连接
+func (m *Manager) connect(ctx context.Context, retries int) error {
+	m.connMu.Lock()
+	defer m.connMu.Unlock()
+
 	m.mu.RLock()
-	if m.closed {
-		m.mu.RUnlock()
+	closed, connected := m.closed, m.client != nil
+	m.mu.RUnlock()
+	if closed {
 		return ErrClosed
 	}
-	m.mu.RUnlock()
+	if connected {
+		return nil
+	}
+
+	m.setState(StateConnecting, nil)
+
+	backoff := m.opts.RetryBackoff
+	var lastErr error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				m.setState(StateDisconnected, ctx.Err())
+				return fmt.Errorf("redis connect failed: %w", ctx.Err())
+			case <-timer.C:
+			}
+			backoff *= 2
+			if backoff > m.opts.MaxRetryBackoff {
+				backoff = m.opts.MaxRetryBackoff
+			}
+		}
 
-	m.connOnce.Do(func() {
-		client := redis.NewClient(&redis.Options{
-			Addr:         m.opts.Address,
-			Password:     m.opts.Password,
-			DB:           m.opts.DB,
-			DialTimeout:  m.opts.DialTimeout,
-			ReadTimeout:  m.opts.ReadTimeout,
-			WriteTimeout: m.opts.WriteTimeout,
-			PoolSize:     m.opts.PoolSize,
-			MinIdleConns: m.opts.MinIdleConns,
-		})
-
-		if err := client.Ping(ctx).Err(); err != nil {
-			m.connErr = fmt.Errorf("redis connect failed: %w", err)
-			return
+		client, err := m.dial(ctx)
+		if err != nil {
+			lastErr = err
+			continue
 		}
+		return m.install(client)
+	}
+
+	m.setState(StateDisconnected, lastErr)
+	return fmt.Errorf("redis connect failed: %w", lastErr)
+}
+
+// reconnect 由后台监控调用，单次尝试建立连接
+// 失败时保持 StateDisconnected 不变，避免每个探测周期都触发状态回调
+func (m *Manager) reconnect(ctx context.Context) error {
+	m.connMu.Lock()
+	defer m.connMu.Unlock()
 
-		m.mu.Lock()
-		m.client = client
+	if _, err := m.currentClient(); err != ErrNotConnected {
+		return err
+	}
+
+	client, err := m.dial(ctx)
+	if err != nil {
+		return err
+	}
+	return m.install(client)
+}
+
+// install 设置已建立的客户端并标记为已连接
+func (m *Manager) install(client *redis.Client) error {
+	m.mu.Lock()
+	if m.closed {
 		m.mu.Unlock()
+		_ = client.Close()
+		return ErrClosed
+	}
+	m.client = client
+	m.mu.Unlock()
+
+	m.setState(StateConnected, nil)
+	return nil
+}
+
+// dial 创建客户端并完成 PING 与脚本预加载
+func (m *Manager) dial(ctx context.Context) (*redis.Client, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:         m.opts.Address,
+		Password:     m.opts.Password,
+		DB:           m.opts.DB,
+		DialTimeout:  m.opts.DialTimeout,
+		ReadTimeout:  m.opts.ReadTimeout,
+		WriteTimeout: m.opts.WriteTimeout,
+		PoolSize:     m.opts.PoolSize,
+		MinIdleConns: m.opts.MinIdleConns,
 	})
+	if len(m.opts.Hooks) > 0 {
+		client.AddHook(&instrumentHook{client: client, hooks: m.opts.Hooks})
+	}
 
-	return m.connErr
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+
+	if err := loadScripts(ctx, client, m.registeredScripts()); err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+	return client, nil
 }
 
-// Close 关闭 Redis 连接。
-// 关闭后 Manager 不可再使用。
+// Close 关闭 Redis 连接，并等待后台连接监控退出，因此不能在 OnStateChange 回调中调用。
+// 关闭后 Manager 不可再使用，所有托管中的订阅也会随之结束。
 func (m *Manager) Close() error {
 	m.mu.Lock()
-	defer m.mu.Unlock()
-
 	if m.closed {
+		m.mu.Unlock()
 		return nil
 	}
 	m.closed = true
+	close(m.stopMonitor)
+	for sub := range m.subs {
+		sub.cancel()
+	}
 
+	var err error
 	if m.client != nil {
-		return m.client.Close()
+		err = m.client.Close()
 	}
-	return nil
+	monitoring := m.monitoring
+	m.mu.Unlock()
+
+	if monitoring {
+		<-m.monitorDone
+	}
+	m.setState(StateClosed, nil)
+	return err
 }
 
 // IsConnected 返回是否已连接到 Redis
 func (m *Manager) IsConnected() bool {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	return m.client != nil && !m.closed
+	return m.client != nil && !m.closed && m.state == StateConnected
 }
 
 // getClient 获取底层 Redis 客户端，内部使用
-func (m *Manager) getClient() (*redis.Client, error) {
+// 启用懒连接时，若尚未连接会在此处发起连接
+func (m *Manager) getClient(ctx context.Context) (*redis.Client, error) {
+	client, err := m.currentClient()
+	if err == ErrNotConnected && m.opts.LazyConnect {
+		if err := m.Connect(ctx); err != nil {
+			return nil, err
+		}
+		return m.currentClient()
+	}
+	return client, err
+}
+
+// currentClient 返回当前客户端，不触发连接
+func (m *Manager) currentClient() (*redis.Client, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
@@ -109,12 +240,12 @@ func (m *Manager) getClient() (*redis.Client, error) {
 
 // GetBytes 获取指定 key 的值（字节切片形式）
 func (m *Manager) GetBytes(ctx context.Context, key string) ([]byte, error) {
-	client, err := m.getClient()
+	client, err := m.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	result, err := client.Get(ctx, key).Bytes()
+	result, err := client.Get(ctx, m.prefixKey(key)).Bytes()
 	if err != nil {
 		if err == redis.Nil {
 			return nil, nil
@@ -126,12 +257,12 @@ func (m *Manager) GetBytes(ctx context.Context, key string) ([]byte, error) {
 
 // SetBytes 设置指定 key 的值（字节切片形式）
 func (m *Manager) SetBytes(ctx context.Context, key string, value []byte, ttl time.Duration) error {
-	client, err := m.getClient()
+	client, err := m.getClient(ctx)
 	if err != nil {
 		return err
 	}
 
-	if err := client.Set(ctx, key, value, ttl).Err(); err != nil {
+	if err := client.Set(ctx, m.prefixKey(key), value, ttl).Err(); err != nil {
 		return fmt.Errorf("redis set: %w", err)
 	}
 	return nil
@@ -143,12 +274,12 @@ func (m *Manager) Del(ctx context.Context, keys ...string) (int64, error) {
 		return 0, nil
 	}
 
-	client, err := m.getClient()
+	client, err := m.getClient(ctx)
 	if err != nil {
 		return 0, err
 	}
 
-	result, err := client.Del(ctx, keys...).Result()
+	result, err := client.Del(ctx, m.prefixKeys(keys)...).Result()
 	if err != nil {
 		return 0, fmt.Errorf("redis del: %w", err)
 	}
@@ -157,12 +288,12 @@ func (m *Manager) Del(ctx context.Context, keys ...string) (int64, error) {
 
 // Exists 检查 key 是否存在
 func (m *Manager) Exists(ctx context.Context, key string) (bool, error) {
-	client, err := m.getClient()
+	client, err := m.getClient(ctx)
 	if err != nil {
 		return false, err
 	}
 
-	result, err := client.Exists(ctx, key).Result()
+	result, err := client.Exists(ctx, m.prefixKey(key)).Result()
 	if err != nil {
 		return false, fmt.Errorf("redis exists: %w", err)
 	}
@@ -172,35 +303,27 @@ func (m *Manager) Exists(ctx context.Context, key string) (bool, error) {
 // ScanKeys 扫描匹配 pattern 的 key
 // pattern 支持通配符，如 "prefix*"、"*suffix"、"*contains*"
 // count 是每次扫描的建议数量（实际返回可能更多或更少）
+// 配置了 KeyPrefix 时，pattern 会自动加上前缀，返回的 key 已去除前缀
+// 此方法会将全部结果收集到一个切片中，key 数量较多时建议使用 Scan 迭代
 func (m *Manager) ScanKeys(ctx context.Context, pattern string, count int64) ([]string, error) {
-	client, err := m.getClient()
-	if err != nil {
-		return nil, err
-	}
-
-	var keys []string
-	iter := client.Scan(ctx, 0, pattern, count).Iterator()
-	for iter.Next(ctx) {
-		keys = append(keys, iter.Val())
-	}
-	if err := iter.Err(); err != nil {
-		return nil, fmt.Errorf("redis scan: %w", err)
-	}
-
-	if keys == nil {
-		keys = []string{}
+	keys := []string{}
+	for key, err := range m.Scan(ctx, pattern, count) {
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
 	}
 	return keys, nil
 }
 
 // SetNX 仅当 key 不存在时设置值，返回是否设置成功
 func (m *Manager) SetNX(ctx context.Context, key string, value string, ttl time.Duration) (bool, error) {
-	client, err := m.getClient()
+	client, err := m.getClient(ctx)
 	if err != nil {
 		return false, err
 	}
 
-	result, err := client.SetNX(ctx, key, value, ttl).Result()
+	result, err := client.SetNX(ctx, m.prefixKey(key), value, ttl).Result()
 	if err != nil {
 		return false, fmt.Errorf("redis setnx: %w", err)
 	}
@@ -208,13 +331,14 @@ func (m *Manager) SetNX(ctx context.Context, key string, value string, ttl time.
 }
 
 // Eval 执行 Lua 脚本
+// 每次调用都会发送完整脚本内容，需要反复执行的脚本建议使用 NewScript 配合 EvalScript。
 func (m *Manager) Eval(ctx context.Context, script string, keys []string, args ...any) (any, error) {
-	client, err := m.getClient()
+	client, err := m.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	result, err := client.Eval(ctx, script, keys, args...).Result()
+	result, err := client.Eval(ctx, script, m.prefixKeys(keys), args...).Result()
 	if err != nil {
 		return nil, fmt.Errorf("redis eval: %w", err)
 	}
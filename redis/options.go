@@ -27,22 +27,72 @@ type Options struct {
 
 	// MinIdleConns 最小空闲连接数
 	MinIdleConns int
+
+	// KeyPrefix 键命名空间前缀，如 "svc:prod:"
+	// 设置后 Manager 会自动为所有 key 参数添加前缀，并在 ScanKeys 返回时去除
+	KeyPrefix string
+
+	// Hooks 命令观测钩子，在 Connect 时注册到底层客户端
+	Hooks []Hook
+
+	// ConnectRetries Connect 失败后的重试次数（不含首次尝试）
+	ConnectRetries int
+
+	// RetryBackoff 首次重试前的等待时间，之后每次翻倍
+	RetryBackoff time.Duration
+
+	// MaxRetryBackoff 重试等待时间上限
+	MaxRetryBackoff time.Duration
+
+	// LazyConnect 是否启用懒连接，启用后首次执行命令时自动连接
+	LazyConnect bool
+
+	// MonitorInterval 后台连接监控的探测间隔，0 表示不启用
+	// 启用后会定期 PING，断开时自动重连并通过 OnStateChange 通知状态变化
+	MonitorInterval time.Duration
+
+	// OnStateChange 连接状态变化回调，可选
+	OnStateChange StateChangeFunc
+
+	// LockRetryInterval 分布式锁、信号量等阻塞获取时的重试间隔
+	LockRetryInterval time.Duration
 }
 
+// minRetryBackoff 重试等待时间下限，避免退避为 0 时重连循环空转
+const minRetryBackoff = 10 * time.Millisecond
+
 // Option 是配置 Manager 的函数类型
 type Option func(*Options)
 
 // defaultOptions 返回默认配置
 func defaultOptions() *Options {
 	return &Options{
-		Address:      "localhost:6379",
-		Password:     "",
-		DB:           0,
-		DialTimeout:  5 * time.Second,
-		ReadTimeout:  3 * time.Second,
-		WriteTimeout: 3 * time.Second,
-		PoolSize:     10,
-		MinIdleConns: 2,
+		Address:         "localhost:6379",
+		Password:        "",
+		DB:              0,
+		DialTimeout:     5 * time.Second,
+		ReadTimeout:     3 * time.Second,
+		WriteTimeout:    3 * time.Second,
+		PoolSize:        10,
+		MinIdleConns:    2,
+		ConnectRetries:  3,
+		RetryBackoff:    200 * time.Millisecond,
+		MaxRetryBackoff: 5 * time.Second,
+
+		LockRetryInterval: 100 * time.Millisecond,
+	}
+}
+
+// normalize 修正非法配置：重试次数不小于 0，退避时间不低于 minRetryBackoff 且不超过上限
+func (o *Options) normalize() {
+	if o.ConnectRetries < 0 {
+		o.ConnectRetries = 0
+	}
+	if o.RetryBackoff < minRetryBackoff {
+		o.RetryBackoff = minRetryBackoff
+	}
+	if o.MaxRetryBackoff < o.RetryBackoff {
+		o.MaxRetryBackoff = o.RetryBackoff
 	}
 }
 
@@ -102,3 +152,58 @@ func WithMinIdleConns(n int) Option {
 	}
 }
 
+// WithKeyPrefix 设置键命名空间前缀
+// 多个服务共用同一 Redis 时可用于隔离缓存前缀、锁名和队列等 key
+func WithKeyPrefix(prefix string) Option {
+	return func(o *Options) {
+		o.KeyPrefix = prefix
+	}
+}
+
+// WithHook 添加命令观测钩子，可多次调用
+func WithHook(hooks ...Hook) Option {
+	return func(o *Options) {
+		o.Hooks = append(o.Hooks, hooks...)
+	}
+}
+
+// WithConnectRetry 设置 Connect 的重试次数与退避时间
+// backoff 为首次重试前的等待时间，之后每次翻倍直至 maxBackoff
+// retries 小于 0 按 0 处理，backoff 不低于 10ms，maxBackoff 不小于 backoff
+func WithConnectRetry(retries int, backoff, maxBackoff time.Duration) Option {
+	return func(o *Options) {
+		o.ConnectRetries = retries
+		o.RetryBackoff = backoff
+		o.MaxRetryBackoff = maxBackoff
+	}
+}
+
+// WithLazyConnect 启用懒连接，首次执行命令时自动建立连接
+func WithLazyConnect(lazy bool) Option {
+	return func(o *Options) {
+		o.LazyConnect = lazy
+	}
+}
+
+// WithMonitor 启用后台连接监控
+// interval 为探测间隔，fn 为状态变化回调（可为 nil）
+func WithMonitor(interval time.Duration, fn StateChangeFunc) Option {
+	return func(o *Options) {
+		o.MonitorInterval = interval
+		o.OnStateChange = fn
+	}
+}
+
+// WithStateChange 设置连接状态变化回调
+func WithStateChange(fn StateChangeFunc) Option {
+	return func(o *Options) {
+		o.OnStateChange = fn
+	}
+}
+
+// WithLockRetryInterval 设置分布式锁、信号量等阻塞获取时的重试间隔
+func WithLockRetryInterval(d time.Duration) Option {
+	return func(o *Options) {
+		o.LockRetryInterval = d
+	}
+}
@@ -0,0 +1,302 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Message 订阅收到的消息
+type Message struct {
+	// Channel 消息所在频道（已去除命名空间前缀）
+	Channel string
+
+	// Pattern 匹配到的订阅模式，仅模式订阅时有值（已去除命名空间前缀）
+	Pattern string
+
+	// Payload 消息内容
+	Payload string
+}
+
+// MessageHandler 消息处理函数，在订阅的后台 goroutine 中串行调用
+type MessageHandler func(ctx context.Context, msg *Message)
+
+// SubscribeOption 配置订阅行为
+type SubscribeOption func(*subscribeConfig)
+
+type subscribeConfig struct {
+	onError func(err error)
+}
+
+// WithErrorHandler 设置订阅错误回调
+// 连接中断、重新订阅失败以及 Topic 消息解码失败时调用
+func WithErrorHandler(fn func(err error)) SubscribeOption {
+	return func(c *subscribeConfig) {
+		c.onError = fn
+	}
+}
+
+// Subscription 表示一个由 Manager 托管的订阅。
+// 订阅在后台 goroutine 中接收消息，连接中断后按退避策略自动重新订阅，
+// 直到 ctx 结束、调用 Close 或 Manager 关闭。
+type Subscription struct {
+	manager  *Manager
+	names    []string
+	pattern  bool
+	handler  MessageHandler
+	cfg      subscribeConfig
+	cancel   context.CancelFunc
+	done     chan struct{}
+	mu       sync.Mutex
+	pubsub   *redis.PubSub
+	closeErr error
+}
+
+// Publish 向频道发布消息，返回收到消息的订阅者数量
+// 配置了 KeyPrefix 时频道名会自动加上前缀
+func (m *Manager) Publish(ctx context.Context, channel string, payload any) (int64, error) {
+	client, err := m.getClient(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := client.Publish(ctx, m.prefixKey(channel), payload).Result()
+	if err != nil {
+		return 0, fmt.Errorf("redis publish: %w", err)
+	}
+	return n, nil
+}
+
+// Subscribe 订阅一个或多个频道，消息交由 handler 在后台 goroutine 中处理。
+// 首次订阅失败时直接返回错误；订阅建立后连接中断会自动重新订阅。
+// 配置了 KeyPrefix 时频道名会自动加上前缀。
+func (m *Manager) Subscribe(ctx context.Context, handler MessageHandler, channels []string, opts ...SubscribeOption) (*Subscription, error) {
+	return m.subscribe(ctx, handler, channels, false, opts)
+}
+
+// PSubscribe 按模式订阅频道，如 "config:*"
+// 配置了 KeyPrefix 时模式会自动加上前缀
+func (m *Manager) PSubscribe(ctx context.Context, handler MessageHandler, patterns []string, opts ...SubscribeOption) (*Subscription, error) {
+	return m.subscribe(ctx, handler, patterns, true, opts)
+}
+
+// subscribe 建立订阅并启动后台接收 goroutine
+func (m *Manager) subscribe(ctx context.Context, handler MessageHandler, names []string, pattern bool, opts []SubscribeOption) (*Subscription, error) {
+	var cfg subscribeConfig
+	for _, o := range opts {
+		o(&cfg)
+	}
+
+	subCtx, cancel := context.WithCancel(ctx)
+	s := &Subscription{
+		manager: m,
+		names:   names,
+		pattern: pattern,
+		handler: handler,
+		cfg:     cfg,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+
+	ps, err := s.open(subCtx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	s.pubsub = ps
+
+	if err := m.trackSubscription(s); err != nil {
+		cancel()
+		_ = ps.Close()
+		return nil, err
+	}
+
+	go s.run(subCtx)
+	return s, nil
+}
+
+// Close 取消订阅并等待后台 goroutine 退出
+func (s *Subscription) Close() error {
+	s.cancel()
+	<-s.done
+	return s.closeErr
+}
+
+// Done 返回订阅结束时关闭的 channel
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// open 使用当前客户端建立订阅并等待服务端确认
+func (s *Subscription) open(ctx context.Context) (*redis.PubSub, error) {
+	client, err := s.manager.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(s.names))
+	var ps *redis.PubSub
+	if s.pattern {
+		for i, n := range s.names {
+			names[i] = s.manager.prefixPattern(n)
+		}
+		ps = client.PSubscribe(ctx, names...)
+	} else {
+		for i, n := range s.names {
+			names[i] = s.manager.prefixKey(n)
+		}
+		ps = client.Subscribe(ctx, names...)
+	}
+
+	if _, err := ps.Receive(ctx); err != nil {
+		_ = ps.Close()
+		return nil, fmt.Errorf("redis subscribe: %w", err)
+	}
+	return ps, nil
+}
+
+// run 循环接收消息，连接中断时关闭旧订阅并按退避策略重新订阅
+func (s *Subscription) run(ctx context.Context) {
+	defer close(s.done)
+	defer s.manager.untrackSubscription(s)
+	defer func() {
+		s.mu.Lock()
+		if s.pubsub != nil {
+			s.closeErr = s.pubsub.Close()
+		}
+		s.mu.Unlock()
+	}()
+
+	backoff := s.manager.opts.RetryBackoff
+	for {
+		msg, err := s.pubsub.ReceiveMessage(ctx)
+		if err == nil {
+			backoff = s.manager.opts.RetryBackoff
+			s.handler(ctx, &Message{
+				Channel: s.manager.stripKey(msg.Channel),
+				Pattern: s.manager.stripPattern(msg.Pattern),
+				Payload: msg.Payload,
+			})
+			continue
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		s.reportError(err)
+
+		// 连接中断：丢弃旧订阅，等待后使用当前客户端重新订阅
+		s.mu.Lock()
+		_ = s.pubsub.Close()
+		s.pubsub = nil
+		s.mu.Unlock()
+
+		for {
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+			backoff *= 2
+			if backoff > s.manager.opts.MaxRetryBackoff {
+				backoff = s.manager.opts.MaxRetryBackoff
+			}
+
+			ps, err := s.open(ctx)
+			if err == nil {
+				s.mu.Lock()
+				s.pubsub = ps
+				s.mu.Unlock()
+				break
+			}
+			if errors.Is(err, ErrClosed) || ctx.Err() != nil {
+				return
+			}
+			s.reportError(err)
+		}
+	}
+}
+
+// reportError 调用错误回调（如已配置）
+func (s *Subscription) reportError(err error) {
+	if s.cfg.onError != nil {
+		s.cfg.onError(err)
+	}
+}
+
+// trackSubscription 登记订阅，Manager 关闭时统一取消
+func (m *Manager) trackSubscription(s *Subscription) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return ErrClosed
+	}
+	m.subs[s] = struct{}{}
+	return nil
+}
+
+// untrackSubscription 移除订阅登记
+func (m *Manager) untrackSubscription(s *Subscription) {
+	m.mu.Lock()
+	delete(m.subs, s)
+	m.mu.Unlock()
+}
+
+// Topic 是绑定频道与消息类型的类型化发布/订阅访问器，消息以 JSON 编码。
+//
+// 使用示例：
+//
+//	topic := redis.NewTopic[ConfigChanged](mgr, "config:changed")
+//	topic.Publish(ctx, ConfigChanged{Version: 2})
+//	sub, err := topic.Subscribe(ctx, func(ctx context.Context, ev ConfigChanged) {
+//		reload(ev.Version)
+//	})
+type Topic[T any] struct {
+	manager *Manager
+	channel string
+}
+
+// NewTopic 创建类型化频道
+func NewTopic[T any](m *Manager, channel string) *Topic[T] {
+	return &Topic[T]{manager: m, channel: channel}
+}
+
+// Channel 返回频道名
+func (t *Topic[T]) Channel() string {
+	return t.channel
+}
+
+// Publish 将 v 编码为 JSON 后发布，返回收到消息的订阅者数量
+func (t *Topic[T]) Publish(ctx context.Context, v T) (int64, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return 0, fmt.Errorf("redis publish marshal: %w", err)
+	}
+	return t.manager.Publish(ctx, t.channel, data)
+}
+
+// Subscribe 订阅频道并将消息解码为 T 后交给 handler
+// 解码失败的消息会被跳过，并通过 WithErrorHandler 配置的回调通知
+func (t *Topic[T]) Subscribe(ctx context.Context, handler func(ctx context.Context, v T), opts ...SubscribeOption) (*Subscription, error) {
+	var cfg subscribeConfig
+	for _, o := range opts {
+		o(&cfg)
+	}
+
+	return t.manager.Subscribe(ctx, func(ctx context.Context, msg *Message) {
+		var v T
+		if err := json.Unmarshal([]byte(msg.Payload), &v); err != nil {
+			if cfg.onError != nil {
+				cfg.onError(fmt.Errorf("redis topic %s unmarshal: %w", msg.Channel, err))
+			}
+			return
+		}
+		handler(ctx, v)
+	}, []string{t.channel}, opts...)
+}
@@ -0,0 +1,197 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 读写锁由三个 key 组成（使用 hash tag 保证位于同一 slot）：
+//   - {key}:w  写锁持有者，字符串，带过期时间
+//   - {key}:r  读锁持有者，有序集合，score 为租约到期时间（毫秒时间戳）
+//   - {key}:wi 写意向，写者阻塞等待期间设置，阻止新的读者进入，避免写者饥饿
+
+// rwReadAcquireScript 获取读锁
+// KEYS[1] 写锁，KEYS[2] 读者集合，KEYS[3] 写意向
+// ARGV[1] 持有者 ID，ARGV[2] 当前时间，ARGV[3] 到期时间，ARGV[4] 租约毫秒数
+var rwReadAcquireScript = NewScript(`
+	if redis.call("exists", KEYS[1]) == 1 then
+		return 0
+	end
+	redis.call("zremrangebyscore", KEYS[2], "-inf", ARGV[2])
+	if not redis.call("zscore", KEYS[2], ARGV[1]) and redis.call("exists", KEYS[3]) == 1 then
+		return 0
+	end
+	redis.call("zadd", KEYS[2], ARGV[3], ARGV[1])
+	if redis.call("pttl", KEYS[2]) < tonumber(ARGV[4]) then
+		redis.call("pexpire", KEYS[2], ARGV[4])
+	end
+	return 1
+`)
+
+// rwWriteAcquireScript 获取写锁
+// KEYS 同上；ARGV[1] 持有者 ID，ARGV[2] 当前时间，ARGV[3] 租约毫秒数，ARGV[4] 是否登记写意向
+var rwWriteAcquireScript = NewScript(`
+	local writer = redis.call("get", KEYS[1])
+	if writer and writer ~= ARGV[1] then
+		return 0
+	end
+	redis.call("zremrangebyscore", KEYS[2], "-inf", ARGV[2])
+	if redis.call("zcard", KEYS[2]) > 0 then
+		if ARGV[4] == "1" then
+			local intent = redis.call("get", KEYS[3])
+			if not intent or intent == ARGV[1] then
+				redis.call("set", KEYS[3], ARGV[1], "PX", ARGV[3])
+			end
+		end
+		return 0
+	end
+	redis.call("set", KEYS[1], ARGV[1], "PX", ARGV[3])
+	if redis.call("get", KEYS[3]) == ARGV[1] then
+		redis.call("del", KEYS[3])
+	end
+	return 1
+`)
+
+// rwWriteReleaseScript 释放写锁及其写意向
+var rwWriteReleaseScript = NewScript(`
+	if redis.call("get", KEYS[3]) == ARGV[1] then
+		redis.call("del", KEYS[3])
+	end
+	if redis.call("get", KEYS[1]) == ARGV[1] then
+		return redis.call("del", KEYS[1])
+	end
+	return 0
+`)
+
+// RWLock 分布式读写锁：多个读者可同时持有读锁，写者独占。
+// RWLock 本身只描述锁资源，通过 ReadLock / WriteLock 创建具体的持有者。
+type RWLock struct {
+	manager *Manager
+	keys    []string
+	expire  time.Duration
+}
+
+// NewRWLock 创建分布式读写锁
+// expire 为每个持有者的租约时长
+func (m *Manager) NewRWLock(key string, expire time.Duration) *RWLock {
+	tagged := "{" + key + "}"
+	return &RWLock{
+		manager: m,
+		keys:    []string{tagged + ":w", tagged + ":r", tagged + ":wi"},
+		expire:  expire,
+	}
+}
+
+// ReadLock 创建一个读锁持有者
+func (rw *RWLock) ReadLock() *ReadLock {
+	return &ReadLock{rw: rw, holder: uuid.New().String()}
+}
+
+// WriteLock 创建一个写锁持有者
+func (rw *RWLock) WriteLock() *WriteLock {
+	return &WriteLock{rw: rw, holder: uuid.New().String()}
+}
+
+// ReadLock 读锁持有者
+type ReadLock struct {
+	rw     *RWLock
+	holder string
+}
+
+// Acquire 尝试获取读锁
+// 写锁被持有或有写者等待时返回 ErrLockAcquireFailed
+func (l *ReadLock) Acquire(ctx context.Context) error {
+	now := time.Now()
+	result, err := l.rw.manager.EvalScript(ctx, rwReadAcquireScript, l.rw.keys,
+		l.holder, now.UnixMilli(), now.Add(l.rw.expire).UnixMilli(), l.rw.expire.Milliseconds())
+	if err != nil {
+		return err
+	}
+	if !scriptOK(result) {
+		return ErrLockAcquireFailed
+	}
+	return nil
+}
+
+// AcquireWait 阻塞等待获取读锁，每隔 LockRetryInterval 重试一次
+func (l *ReadLock) AcquireWait(ctx context.Context) error {
+	return l.rw.manager.waitAcquire(ctx, l.Acquire, l.Release)
+}
+
+// Release 释放读锁
+func (l *ReadLock) Release(ctx context.Context) error {
+	_, err := l.rw.manager.EvalScript(ctx, semReleaseScript, l.rw.keys[1:2], l.holder)
+	return err
+}
+
+// Refresh 续期读锁，租约已过期时返回 ErrLockNotHeld
+func (l *ReadLock) Refresh(ctx context.Context) error {
+	now := time.Now()
+	result, err := l.rw.manager.EvalScript(ctx, semRefreshScript, l.rw.keys[1:2],
+		l.holder, now.UnixMilli(), now.Add(l.rw.expire).UnixMilli(), l.rw.expire.Milliseconds())
+	if err != nil {
+		return err
+	}
+	if !scriptOK(result) {
+		return ErrLockNotHeld
+	}
+	return nil
+}
+
+// WriteLock 写锁持有者
+type WriteLock struct {
+	rw     *RWLock
+	holder string
+}
+
+// Acquire 尝试获取写锁
+// 存在其他写者或读者时返回 ErrLockAcquireFailed
+func (l *WriteLock) Acquire(ctx context.Context) error {
+	return l.acquire(ctx, false)
+}
+
+// AcquireWait 阻塞等待获取写锁，每隔 LockRetryInterval 重试一次。
+// 等待期间会登记写意向，阻止新的读者进入，已持有读锁的读者释放后即可获取。
+// 放弃等待时撤销写意向。
+func (l *WriteLock) AcquireWait(ctx context.Context) error {
+	return l.rw.manager.waitAcquire(ctx, func(ctx context.Context) error {
+		return l.acquire(ctx, true)
+	}, l.Release)
+}
+
+// acquire 执行写锁获取脚本
+func (l *WriteLock) acquire(ctx context.Context, intent bool) error {
+	flag := "0"
+	if intent {
+		flag = "1"
+	}
+	result, err := l.rw.manager.EvalScript(ctx, rwWriteAcquireScript, l.rw.keys,
+		l.holder, time.Now().UnixMilli(), l.rw.expire.Milliseconds(), flag)
+	if err != nil {
+		return err
+	}
+	if !scriptOK(result) {
+		return ErrLockAcquireFailed
+	}
+	return nil
+}
+
+// Release 释放写锁
+func (l *WriteLock) Release(ctx context.Context) error {
+	_, err := l.rw.manager.EvalScript(ctx, rwWriteReleaseScript, l.rw.keys, l.holder)
+	return err
+}
+
+// Refresh 续期写锁，租约已过期时返回 ErrLockNotHeld
+func (l *WriteLock) Refresh(ctx context.Context) error {
+	result, err := l.rw.manager.EvalScript(ctx, refreshLockScript, l.rw.keys[:1], l.holder, l.rw.expire.Milliseconds())
+	if err != nil {
+		return err
+	}
+	if !scriptOK(result) {
+		return ErrLockNotHeld
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"time"
+)
+
+// DefaultDeleteBatchSize DeleteByPattern 默认每批删除的 key 数量
+const DefaultDeleteBatchSize = 500
+
+// Scan 以迭代器形式扫描匹配 pattern 的 key，逐个产出，不在内存中汇总。
+// count 是每次 SCAN 的建议数量。
+// 配置了 KeyPrefix 时，pattern 会自动加上前缀，产出的 key 已去除前缀。
+// 扫描出错时产出 ("", err) 后结束迭代。
+//
+// 使用示例：
+//
+//	for key, err := range mgr.Scan(ctx, "user:*", 100) {
+//		if err != nil {
+//			return err
+//		}
+//		// 处理 key
+//	}
+func (m *Manager) Scan(ctx context.Context, pattern string, count int64) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		for key, err := range m.scanRaw(ctx, pattern, count) {
+			if err != nil {
+				yield("", err)
+				return
+			}
+			if !yield(m.stripKey(key), nil) {
+				return
+			}
+		}
+	}
+}
+
+// DeleteByPattern 分批删除匹配 pattern 的 key，返回实际删除的数量。
+// 使用 UNLINK 在服务端异步回收内存，避免大 key 阻塞 Redis。
+// batchSize 为每批删除的 key 数量（<= 0 时使用 DefaultDeleteBatchSize），
+// interval 为相邻两批之间的等待时间，用于限制删除速率（0 表示不等待）。
+// 配置了 KeyPrefix 时，pattern 会自动加上前缀。
+func (m *Manager) DeleteByPattern(ctx context.Context, pattern string, batchSize int64, interval time.Duration) (int64, error) {
+	client, err := m.getClient(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if batchSize <= 0 {
+		batchSize = DefaultDeleteBatchSize
+	}
+
+	var (
+		deleted int64
+		batches int
+		batch   = make([]string, 0, batchSize)
+	)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if batches > 0 && interval > 0 {
+			timer := time.NewTimer(interval)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+
+		// key 来自 SCAN，已包含前缀，直接使用底层客户端
+		n, err := client.Unlink(ctx, batch...).Result()
+		if err != nil {
+			return fmt.Errorf("redis unlink: %w", err)
+		}
+		deleted += n
+		batches++
+		batch = batch[:0]
+		return nil
+	}
+
+	for key, err := range m.scanRaw(ctx, pattern, batchSize) {
+		if err != nil {
+			return deleted, err
+		}
+		batch = append(batch, key)
+		if int64(len(batch)) >= batchSize {
+			if err := flush(); err != nil {
+				return deleted, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return deleted, err
+	}
+	return deleted, nil
+}
+
+// scanRaw 扫描并产出带命名空间前缀的原始 key
+func (m *Manager) scanRaw(ctx context.Context, pattern string, count int64) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		client, err := m.getClient(ctx)
+		if err != nil {
+			yield("", err)
+			return
+		}
+
+		it := client.Scan(ctx, 0, m.prefixPattern(pattern), count).Iterator()
+		for it.Next(ctx) {
+			if !yield(it.Val(), nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			yield("", fmt.Errorf("redis scan: %w", err))
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package redis
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Script 表示一段可缓存的 Lua 脚本。
+// Script 创建时即计算 SHA1，执行时优先使用 EVALSHA，
+// 服务端缺失脚本缓存（NOSCRIPT）时透明回退到 EVAL。
+//
+// Script 是只读的，可在多个 goroutine 和多个 Manager 之间共享。
+type Script struct {
+	src  string
+	hash string
+}
+
+// NewScript 创建 Lua 脚本。
+func NewScript(src string) *Script {
+	h := sha1.Sum([]byte(src))
+	return &Script{
+		src:  src,
+		hash: hex.EncodeToString(h[:]),
+	}
+}
+
+// Hash 返回脚本的 SHA1 摘要（十六进制）
+func (s *Script) Hash() string {
+	return s.hash
+}
+
+// Source 返回脚本源码
+func (s *Script) Source() string {
+	return s.src
+}
+
+// RegisterScript 将脚本注册到 Manager。
+// 已注册的脚本会在 Connect 成功后通过 SCRIPT LOAD 预加载，
+// 并在检测到服务端脚本缓存丢失（如主从切换、SCRIPT FLUSH）后整体重新加载。
+// 若 Manager 已连接，注册时会立即尝试加载。
+func (m *Manager) RegisterScript(ctx context.Context, scripts ...*Script) error {
+	m.scriptsMu.Lock()
+	for _, s := range scripts {
+		m.scripts[s.hash] = s
+	}
+	m.scriptsMu.Unlock()
+
+	client, err := m.currentClient()
+	if err != nil {
+		// 尚未连接时只登记，等待 Connect 时统一加载
+		return nil
+	}
+	return loadScripts(ctx, client, scripts)
+}
+
+// EvalScript 执行已缓存的 Lua 脚本。
+// 优先发送 EVALSHA，收到 NOSCRIPT 错误时回退为 EVAL 并重新加载所有已注册脚本。
+// 未注册的脚本会在首次执行时自动注册。
+// 配置了 KeyPrefix 时 keys 会自动加上前缀，脚本内部通过 KEYS 访问的即为完整 key。
+func (m *Manager) EvalScript(ctx context.Context, script *Script, keys []string, args ...any) (any, error) {
+	client, err := m.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	m.ensureScript(script)
+	keys = m.prefixKeys(keys)
+
+	result, err := client.EvalSha(ctx, script.hash, keys, args...).Result()
+	if err != nil && isNoScriptErr(err) {
+		result, err = client.Eval(ctx, script.src, keys, args...).Result()
+		if err == nil || err == redis.Nil {
+			// 脚本缓存已丢失，通常意味着发生了故障转移，重新加载全部脚本
+			_ = loadScripts(ctx, client, m.registeredScripts())
+		}
+	}
+	if err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("redis evalsha: %w", err)
+	}
+	return result, nil
+}
+
+// ensureScript 确保脚本已登记
+func (m *Manager) ensureScript(script *Script) {
+	m.scriptsMu.RLock()
+	_, ok := m.scripts[script.hash]
+	m.scriptsMu.RUnlock()
+	if ok {
+		return
+	}
+
+	m.scriptsMu.Lock()
+	m.scripts[script.hash] = script
+	m.scriptsMu.Unlock()
+}
+
+// registeredScripts 返回当前已登记脚本的快照
+func (m *Manager) registeredScripts() []*Script {
+	m.scriptsMu.RLock()
+	defer m.scriptsMu.RUnlock()
+
+	scripts := make([]*Script, 0, len(m.scripts))
+	for _, s := range m.scripts {
+		scripts = append(scripts, s)
+	}
+	return scripts
+}
+
+// loadScripts 使用 pipeline 批量执行 SCRIPT LOAD
+func loadScripts(ctx context.Context, client *redis.Client, scripts []*Script) error {
+	if len(scripts) == 0 {
+		return nil
+	}
+
+	pipe := client.Pipeline()
+	for _, s := range scripts {
+		pipe.ScriptLoad(ctx, s.src)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("redis script load: %w", err)
+	}
+	return nil
+}
+
+// isNoScriptErr 判断是否为服务端脚本缓存缺失错误
+func isNoScriptErr(err error) bool {
+	return strings.HasPrefix(err.Error(), "NOSCRIPT")
+}
@@ -0,0 +1,110 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 信号量使用有序集合保存持有者，score 为租约到期时间（毫秒时间戳）。
+// 每次操作前先清理已到期的持有者，因此崩溃进程占用的名额会在租约到期后自动释放。
+// 到期判断使用客户端时间，各节点之间需要保持时钟同步。
+
+// semAcquireScript 获取信号量名额
+// KEYS[1] 信号量 key
+// ARGV[1] 持有者 ID，ARGV[2] 名额上限，ARGV[3] 当前时间，ARGV[4] 到期时间，ARGV[5] 租约毫秒数
+var semAcquireScript = NewScript(`
+	redis.call("zremrangebyscore", KEYS[1], "-inf", ARGV[3])
+	if not redis.call("zscore", KEYS[1], ARGV[1]) and redis.call("zcard", KEYS[1]) >= tonumber(ARGV[2]) then
+		return 0
+	end
+	redis.call("zadd", KEYS[1], ARGV[4], ARGV[1])
+	if redis.call("pttl", KEYS[1]) < tonumber(ARGV[5]) then
+		redis.call("pexpire", KEYS[1], ARGV[5])
+	end
+	return 1
+`)
+
+// semReleaseScript 释放信号量名额
+var semReleaseScript = NewScript(`
+	return redis.call("zrem", KEYS[1], ARGV[1])
+`)
+
+// semRefreshScript 续期信号量名额，名额已失效时返回 0
+// ARGV[1] 持有者 ID，ARGV[2] 当前时间，ARGV[3] 到期时间，ARGV[4] 租约毫秒数
+var semRefreshScript = NewScript(`
+	redis.call("zremrangebyscore", KEYS[1], "-inf", ARGV[2])
+	if not redis.call("zscore", KEYS[1], ARGV[1]) then
+		return 0
+	end
+	redis.call("zadd", KEYS[1], "XX", ARGV[3], ARGV[1])
+	if redis.call("pttl", KEYS[1]) < tonumber(ARGV[4]) then
+		redis.call("pexpire", KEYS[1], ARGV[4])
+	end
+	return 1
+`)
+
+// Semaphore 分布式计数信号量，限制同一 key 下最多 limit 个持有者同时持有。
+// 每个 Semaphore 实例代表一个持有者，不可在多个并发任务间共享。
+type Semaphore struct {
+	manager *Manager
+	key     string
+	holder  string
+	limit   int64
+	lease   time.Duration
+}
+
+// NewSemaphore 创建一个新的信号量持有者
+// limit 为全局并发上限，lease 为每个持有者的租约时长
+func (m *Manager) NewSemaphore(key string, limit int64, lease time.Duration) *Semaphore {
+	return &Semaphore{
+		manager: m,
+		key:     key,
+		holder:  uuid.New().String(),
+		limit:   limit,
+		lease:   lease,
+	}
+}
+
+// Acquire 尝试获取一个名额
+// 名额已满时返回 ErrLockAcquireFailed；已持有时相当于续期
+func (s *Semaphore) Acquire(ctx context.Context) error {
+	now := time.Now()
+	result, err := s.manager.EvalScript(ctx, semAcquireScript, []string{s.key},
+		s.holder, s.limit, now.UnixMilli(), now.Add(s.lease).UnixMilli(), s.lease.Milliseconds())
+	if err != nil {
+		return err
+	}
+	if !scriptOK(result) {
+		return ErrLockAcquireFailed
+	}
+	return nil
+}
+
+// AcquireWait 阻塞等待获取名额，每隔 LockRetryInterval 重试一次
+// ctx 结束时返回 ctx.Err()
+func (s *Semaphore) AcquireWait(ctx context.Context) error {
+	return s.manager.waitAcquire(ctx, s.Acquire, s.Release)
+}
+
+// Release 释放持有的名额
+func (s *Semaphore) Release(ctx context.Context) error {
+	_, err := s.manager.EvalScript(ctx, semReleaseScript, []string{s.key}, s.holder)
+	return err
+}
+
+// Refresh 将名额租约重置为 lease
+// 名额已过期被清理时返回 ErrLockNotHeld
+func (s *Semaphore) Refresh(ctx context.Context) error {
+	now := time.Now()
+	result, err := s.manager.EvalScript(ctx, semRefreshScript, []string{s.key},
+		s.holder, now.UnixMilli(), now.Add(s.lease).UnixMilli(), s.lease.Milliseconds())
+	if err != nil {
+		return err
+	}
+	if !scriptOK(result) {
+		return ErrLockNotHeld
+	}
+	return nil
+}
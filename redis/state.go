@@ -0,0 +1,138 @@
+package redis
+
+import (
+	"context"
+	"time"
+)
+
+// ConnState 表示 Manager 的连接状态
+type ConnState int
+
+const (
+	// StateDisconnected 未连接或连接已断开
+	StateDisconnected ConnState = iota
+
+	// StateConnecting 正在建立连接
+	StateConnecting
+
+	// StateConnected 已连接
+	StateConnected
+
+	// StateClosed Manager 已关闭
+	StateClosed
+)
+
+// String 返回连接状态的文本表示
+func (s ConnState) String() string {
+	switch s {
+	case StateDisconnected:
+		return "disconnected"
+	case StateConnecting:
+		return "connecting"
+	case StateConnected:
+		return "connected"
+	case StateClosed:
+		return "closed"
+	default:
+		return "unknown"
+	}
+}
+
+// StateChangeFunc 连接状态变化回调
+// err 为导致状态变化的错误（如有）
+type StateChangeFunc func(from, to ConnState, err error)
+
+// State 返回当前连接状态
+func (m *Manager) State() ConnState {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.state
+}
+
+// setState 更新连接状态，状态发生变化时触发回调
+func (m *Manager) setState(to ConnState, err error) {
+	m.mu.Lock()
+	from := m.state
+	if from == to || (from == StateClosed && to != StateClosed) {
+		m.mu.Unlock()
+		return
+	}
+	m.state = to
+	m.mu.Unlock()
+
+	if m.opts.OnStateChange != nil {
+		m.opts.OnStateChange(from, to, err)
+	}
+}
+
+// startMonitor 启动后台连接监控（仅启动一次）
+func (m *Manager) startMonitor() {
+	if m.opts.MonitorInterval <= 0 {
+		return
+	}
+	m.monitorOnce.Do(func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if m.closed {
+			return
+		}
+		m.monitoring = true
+		go m.monitor()
+	})
+}
+
+// monitor 定期 PING 检查连接状态：
+// 尚未建立连接时尝试重连，PING 失败标记为断开，恢复后重新加载脚本
+func (m *Manager) monitor() {
+	defer close(m.monitorDone)
+
+	ticker := time.NewTicker(m.opts.MonitorInterval)
+	defer ticker.Stop()
+
+	// Close 时取消进行中的探测，避免 Close 等待一个完整的拨号超时
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-m.stopMonitor:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		select {
+		case <-m.stopMonitor:
+			return
+		case <-ticker.C:
+			m.probe(ctx)
+		}
+	}
+}
+
+// probe 执行一次连接探测
+func (m *Manager) probe(parent context.Context) {
+	ctx, cancel := context.WithTimeout(parent, m.opts.DialTimeout)
+	defer cancel()
+
+	client, err := m.currentClient()
+	if err == ErrClosed {
+		return
+	}
+	if err == ErrNotConnected {
+		// 单次尝试，重试节奏由监控周期控制
+		_ = m.reconnect(ctx)
+		return
+	}
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		m.setState(StateDisconnected, err)
+		return
+	}
+
+	if m.State() == StateDisconnected {
+		// 断线恢复后服务端可能已切换，脚本缓存需要重新加载
+		_ = loadScripts(ctx, client, m.registeredScripts())
+		m.setState(StateConnected, nil)
+	}
+}